@@ -0,0 +1,129 @@
+package autograd
+
+import "fmt"
+
+// ConvOutputSize returns the spatial output size of a convolution or pooling window
+func ConvOutputSize(inputSize, kernel, stride, padding, dilation int) int {
+	return (inputSize+2*padding-dilation*(kernel-1)-1)/stride + 1
+}
+
+// ConvTransposeOutputSize returns the spatial output size of a transposed convolution
+func ConvTransposeOutputSize(inputSize, kernel, stride, padding, outputPadding, dilation int) int {
+	return (inputSize-1)*stride - 2*padding + dilation*(kernel-1) + outputPadding + 1
+}
+
+// Im2Col unfolds sliding kernelH x kernelW patches of an NCHW input into rows.
+// The result has shape (N*outH*outW, C*kernelH*kernelW), so a convolution becomes
+// a single matrix product with the flattened kernel.
+func (t *Tape) Im2Col(x *Variable, kernelH, kernelW, stride, padding, dilation int) *Variable {
+	shape := x.Shape()
+	if len(shape) != 4 {
+		panic(fmt.Sprintf("autograd: Im2Col expects NCHW input, got shape %v", shape))
+	}
+	return t.im2col(x, [2]int{kernelH, kernelW}, [2]int{stride, stride}, [2]int{padding, padding}, [2]int{dilation, dilation})
+}
+
+// Im2Col1D unfolds sliding windows of an (N, C, L) input into rows of shape
+// (N*outL, C*kernel), the 1D counterpart of Im2Col
+func (t *Tape) Im2Col1D(x *Variable, kernel, stride, padding, dilation int) *Variable {
+	shape := x.Shape()
+	if len(shape) != 3 {
+		panic(fmt.Sprintf("autograd: Im2Col1D expects (N, C, L) input, got shape %v", shape))
+	}
+	planes := t.Reshape(x, shape[0], shape[1], 1, shape[2])
+	return t.im2col(planes, [2]int{1, kernel}, [2]int{1, stride}, [2]int{0, padding}, [2]int{1, dilation})
+}
+
+// im2col unfolds an NCHW input with per-axis {height, width} window settings
+func (t *Tape) im2col(x *Variable, kernel, stride, padding, dilation [2]int) *Variable {
+	shape := x.Shape()
+	n, c, h, w := shape[0], shape[1], shape[2], shape[3]
+	outH := ConvOutputSize(h, kernel[0], stride[0], padding[0], dilation[0])
+	outW := ConvOutputSize(w, kernel[1], stride[1], padding[1], dilation[1])
+	if outH <= 0 || outW <= 0 {
+		panic(fmt.Sprintf("autograd: Im2Col kernel %dx%d (dilation %v, padding %v) does not fit input %dx%d",
+			kernel[0], kernel[1], dilation, padding, h, w))
+	}
+
+	xData := x.Data()
+	index := patchIndex([4]int{n, c, h, w}, outH, outW, kernel, stride, padding, dilation)
+	data := make([]float64, len(index))
+	for i, src := range index {
+		if src >= 0 {
+			data[i] = xData[src]
+		}
+	}
+
+	return t.record([]int{n * outH * outW, c * kernel[0] * kernel[1]}, data, func(grad []float64) {
+		// col2im: scatter-add every patch gradient back to its input position
+		gradX := make([]float64, len(xData))
+		for i, g := range grad {
+			if index[i] >= 0 {
+				gradX[index[i]] += g
+			}
+		}
+		x.accumulate(gradX)
+	}, x)
+}
+
+// Col2Im folds rows of kernelH x kernelW patches back into an (n, c, h, w) image,
+// summing overlapping contributions. It is the adjoint of Im2Col, so cols must have
+// shape (n*gridH*gridW, c*kernelH*kernelW) where gridH x gridW is the number of
+// windows Im2Col would extract from an h x w image.
+func (t *Tape) Col2Im(cols *Variable, n, c, h, w, kernelH, kernelW, stride, padding, dilation int) *Variable {
+	gridH := ConvOutputSize(h, kernelH, stride, padding, dilation)
+	gridW := ConvOutputSize(w, kernelW, stride, padding, dilation)
+	expected := []int{n * gridH * gridW, c * kernelH * kernelW}
+	if shape := cols.Shape(); len(shape) != 2 || shape[0] != expected[0] || shape[1] != expected[1] {
+		panic(fmt.Sprintf("autograd: Col2Im expects columns %v for a %dx%d image, got shape %v", expected, h, w, shape))
+	}
+
+	kernel, strides, pads, dilations := [2]int{kernelH, kernelW}, [2]int{stride, stride}, [2]int{padding, padding}, [2]int{dilation, dilation}
+	index := patchIndex([4]int{n, c, h, w}, gridH, gridW, kernel, strides, pads, dilations)
+	colsData := cols.Data()
+	data := make([]float64, n*c*h*w)
+	for i, dst := range index {
+		if dst >= 0 {
+			data[dst] += colsData[i]
+		}
+	}
+
+	return t.record([]int{n, c, h, w}, data, func(grad []float64) {
+		gradCols := make([]float64, len(index))
+		for i, src := range index {
+			if src >= 0 {
+				gradCols[i] = grad[src]
+			}
+		}
+		cols.accumulate(gradCols)
+	}, cols)
+}
+
+// patchIndex maps every element of the im2col matrix of an NCHW image to its
+// flat image position, or -1 where the window covers padding
+func patchIndex(image [4]int, outH, outW int, kernel, stride, padding, dilation [2]int) []int {
+	n, c, h, w := image[0], image[1], image[2], image[3]
+	index := make([]int, n*outH*outW*c*kernel[0]*kernel[1])
+	i := 0
+	for b := 0; b < n; b++ {
+		for oh := 0; oh < outH; oh++ {
+			for ow := 0; ow < outW; ow++ {
+				for ch := 0; ch < c; ch++ {
+					for kh := 0; kh < kernel[0]; kh++ {
+						ih := oh*stride[0] - padding[0] + kh*dilation[0]
+						for kw := 0; kw < kernel[1]; kw++ {
+							iw := ow*stride[1] - padding[1] + kw*dilation[1]
+							if ih < 0 || ih >= h || iw < 0 || iw >= w {
+								index[i] = -1
+							} else {
+								index[i] = ((b*c+ch)*h+ih)*w + iw
+							}
+							i++
+						}
+					}
+				}
+			}
+		}
+	}
+	return index
+}
@@ -0,0 +1,39 @@
+package autograd
+
+import "fmt"
+
+// Embedding looks up rows of a 2D table for every index, producing (len(indices), dim).
+// Gradients are scattered only into the looked-up rows; rows equal to paddingIdx
+// receive no gradient (pass a negative paddingIdx to disable this).
+func (t *Tape) Embedding(table *Variable, indices []int, paddingIdx int) *Variable {
+	shape := table.Shape()
+	if len(shape) != 2 {
+		panic(fmt.Sprintf("autograd: Embedding expects a 2D table, got shape %v", shape))
+	}
+	numEmbeddings, dim := shape[0], shape[1]
+
+	tableData := table.Data()
+	data := make([]float64, len(indices)*dim)
+	for i, idx := range indices {
+		if idx < 0 || idx >= numEmbeddings {
+			panic(fmt.Sprintf("autograd: Embedding index %d out of range [0, %d)", idx, numEmbeddings))
+		}
+		copy(data[i*dim:(i+1)*dim], tableData[idx*dim:(idx+1)*dim])
+	}
+
+	return t.record([]int{len(indices), dim}, data, func(grad []float64) {
+		rows := indices
+		rowGrad := grad
+		if paddingIdx >= 0 {
+			rows = make([]int, 0, len(indices))
+			rowGrad = make([]float64, 0, len(grad))
+			for i, idx := range indices {
+				if idx != paddingIdx {
+					rows = append(rows, idx)
+					rowGrad = append(rowGrad, grad[i*dim:(i+1)*dim]...)
+				}
+			}
+		}
+		table.accumulateRows(rows, dim, rowGrad)
+	}, table)
+}
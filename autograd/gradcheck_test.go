@@ -0,0 +1,310 @@
+package autograd_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// opFunc records an operation on the given inputs
+type opFunc func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable
+
+// variable wraps data in a leaf variable of the given shape that requires gradients
+func variable(data []float64, shape ...int) *autograd.Variable {
+	return autograd.NewVariable(tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data)), true)
+}
+
+// normal returns a leaf variable of the given shape with standard normal entries
+func normal(rng *rand.Rand, shape ...int) *autograd.Variable {
+	data := make([]float64, tensor.Shape(shape).TotalSize())
+	for i := range data {
+		data[i] = rng.NormFloat64()
+	}
+	return variable(data, shape...)
+}
+
+// positive returns a leaf variable of the given shape with entries in [0.5, 2)
+func positive(rng *rand.Rand, shape ...int) *autograd.Variable {
+	data := make([]float64, tensor.Shape(shape).TotalSize())
+	for i := range data {
+		data[i] = 0.5 + 1.5*rng.Float64()
+	}
+	return variable(data, shape...)
+}
+
+// constant marks v as not requiring gradients
+func constant(v *autograd.Variable) *autograd.Variable {
+	v.RequiresGrad = false
+	return v
+}
+
+// checkOp compares the gradients Backward gives every input with central finite differences
+// of sum(seed * op(inputs)) for a fixed random seed
+func checkOp(t *testing.T, rng *rand.Rand, inputs []*autograd.Variable, op opFunc) {
+	t.Helper()
+	const h, tolerance = 1e-6, 1e-6
+
+	tape := autograd.NewTape()
+	out := op(tape, inputs)
+	seed := make([]float64, len(out.Data()))
+	for i := range seed {
+		seed[i] = rng.NormFloat64()
+	}
+	for _, in := range inputs {
+		in.ZeroGrad()
+	}
+	tape.Backward(out, tensor.New(tensor.WithShape(out.Shape()...), tensor.WithBacking(seed)))
+
+	objective := func() float64 {
+		sum := 0.0
+		for i, v := range op(autograd.NewTape(), inputs).Data() {
+			sum += seed[i] * v
+		}
+		return sum
+	}
+	for n, in := range inputs {
+		if !in.RequiresGrad {
+			continue
+		}
+		var grad []float64
+		if in.Grad != nil {
+			grad = in.Grad.Data().([]float64)
+		}
+		data := in.Data()
+		for i := range data {
+			orig := data[i]
+			data[i] = orig + h
+			plus := objective()
+			data[i] = orig - h
+			minus := objective()
+			data[i] = orig
+
+			numeric := (plus - minus) / (2 * h)
+			analytic := 0.0
+			if grad != nil {
+				analytic = grad[i]
+			}
+			if math.Abs(numeric-analytic) > tolerance {
+				t.Fatalf("input %d gradient[%d] = %v, finite differences give %v", n, i, analytic, numeric)
+			}
+		}
+	}
+}
+
+// opCase is one operation and the inputs it is checked at
+type opCase struct {
+	name   string
+	inputs []*autograd.Variable
+	op     opFunc
+}
+
+// runOpCases gradient-checks every case
+func runOpCases(t *testing.T, rng *rand.Rand, cases []opCase) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checkOp(t, rng, c.inputs, c.op)
+		})
+	}
+}
+
+func TestElementwiseOpGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	unary := func(f func(t *autograd.Tape, a *autograd.Variable) *autograd.Variable) opFunc {
+		return func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable { return f(t, in[0]) }
+	}
+	binary := func(f func(t *autograd.Tape, a, b *autograd.Variable) *autograd.Variable) opFunc {
+		return func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable { return f(t, in[0], in[1]) }
+	}
+
+	runOpCases(t, rng, []opCase{
+		{"Exp", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).Exp)},
+		{"Log", []*autograd.Variable{positive(rng, 3, 4)}, unary((*autograd.Tape).Log)},
+		{"Square", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).Square)},
+		{"Sqrt", []*autograd.Variable{positive(rng, 3, 4)}, unary((*autograd.Tape).Sqrt)},
+		{"Tanh", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).Tanh)},
+		{"Sigmoid", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).Sigmoid)},
+		{"ReLU", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).ReLU)},
+		{"LeakyReLU", []*autograd.Variable{normal(rng, 3, 4)}, unary(func(t *autograd.Tape, a *autograd.Variable) *autograd.Variable {
+			return t.LeakyReLU(a, 0.1)
+		})},
+		{"SiLU", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).SiLU)},
+		{"Softmax", []*autograd.Variable{normal(rng, 2, 3, 4)}, unary((*autograd.Tape).Softmax)},
+		{"LogSoftmax", []*autograd.Variable{normal(rng, 2, 3, 4)}, unary((*autograd.Tape).LogSoftmax)},
+		{"Scale", []*autograd.Variable{normal(rng, 3, 4)}, unary(func(t *autograd.Tape, a *autograd.Variable) *autograd.Variable {
+			return t.Scale(a, -2.5)
+		})},
+		{"AddScalar", []*autograd.Variable{normal(rng, 3, 4)}, unary(func(t *autograd.Tape, a *autograd.Variable) *autograd.Variable {
+			return t.AddScalar(a, 1.5)
+		})},
+		{"Neg", []*autograd.Variable{normal(rng, 3, 4)}, unary((*autograd.Tape).Neg)},
+		{"Add", []*autograd.Variable{normal(rng, 3, 4), normal(rng, 3, 4)}, binary((*autograd.Tape).Add)},
+		{"Sub", []*autograd.Variable{normal(rng, 3, 4), normal(rng, 3, 4)}, binary((*autograd.Tape).Sub)},
+		{"Mul", []*autograd.Variable{normal(rng, 3, 4), normal(rng, 3, 4)}, binary((*autograd.Tape).Mul)},
+		{"Div", []*autograd.Variable{normal(rng, 3, 4), positive(rng, 3, 4)}, binary((*autograd.Tape).Div)},
+		{"AddRow", []*autograd.Variable{normal(rng, 2, 3, 4), normal(rng, 4)}, binary((*autograd.Tape).AddRow)},
+		{"MulRow", []*autograd.Variable{normal(rng, 2, 3, 4), normal(rng, 4)}, binary((*autograd.Tape).MulRow)},
+		{"Pow", []*autograd.Variable{positive(rng, 3, 4)}, unary(func(t *autograd.Tape, a *autograd.Variable) *autograd.Variable {
+			return t.Pow(a, -0.5)
+		})},
+		{"Pow integer", []*autograd.Variable{normal(rng, 3, 4)}, unary(func(t *autograd.Tape, a *autograd.Variable) *autograd.Variable {
+			return t.Pow(a, 3)
+		})},
+		{"Mul by constant", []*autograd.Variable{normal(rng, 3, 4), constant(normal(rng, 3, 4))}, binary((*autograd.Tape).Mul)},
+	})
+}
+
+func TestShapeOpGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	runOpCases(t, rng, []opCase{
+		{"MatMul", []*autograd.Variable{normal(rng, 3, 4), normal(rng, 4, 2)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MatMul(in[0], in[1])
+		}},
+		{"Transpose", []*autograd.Variable{normal(rng, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Transpose(in[0])
+		}},
+		{"Reshape", []*autograd.Variable{normal(rng, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Reshape(in[0], 2, 6)
+		}},
+		{"Sum", []*autograd.Variable{normal(rng, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Sum(in[0])
+		}},
+		{"Mean", []*autograd.Variable{normal(rng, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Mean(in[0])
+		}},
+		{"SumRows", []*autograd.Variable{normal(rng, 2, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.SumRows(in[0])
+		}},
+		{"MeanRows", []*autograd.Variable{normal(rng, 2, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MeanRows(in[0])
+		}},
+		{"Embedding", []*autograd.Variable{normal(rng, 5, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Embedding(in[0], []int{4, 0, 4, 2}, -1)
+		}},
+		{"Embedding with padding", []*autograd.Variable{normal(rng, 5, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			// padding lookups get no gradient, so they are masked out of the objective as well
+			return t.Mul(t.Embedding(in[0], []int{1, 3, 1, 0}, 1), constant(variable([]float64{0, 0, 0, 1, 1, 1, 0, 0, 0, 1, 1, 1}, 4, 3)))
+		}},
+		{"shared input", []*autograd.Variable{normal(rng, 3, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MatMul(t.Tanh(in[0]), t.Transpose(in[0]))
+		}},
+		{"Permute", []*autograd.Variable{normal(rng, 2, 3, 4, 5)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Permute(in[0], 2, 0, 3, 1)
+		}},
+		{"BatchMatMul", []*autograd.Variable{normal(rng, 3, 2, 4), normal(rng, 3, 4, 5)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.BatchMatMul(in[0], in[1])
+		}},
+		{"Narrow", []*autograd.Variable{normal(rng, 2, 6, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Narrow(in[0], 1, 2, 3)
+		}},
+		{"Concat", []*autograd.Variable{normal(rng, 2, 1, 3), constant(normal(rng, 2, 2, 3)), normal(rng, 2, 3, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Concat(1, in...)
+		}},
+	})
+}
+
+func TestWindowOpGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	runOpCases(t, rng, []opCase{
+		{"Im2Col", []*autograd.Variable{normal(rng, 2, 2, 4, 5)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Im2Col(in[0], 2, 3, 1, 0, 1)
+		}},
+		{"Im2Col stride padding dilation", []*autograd.Variable{normal(rng, 1, 2, 6, 5)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Im2Col(in[0], 3, 2, 2, 1, 2)
+		}},
+		{"MaxPool2D", []*autograd.Variable{normal(rng, 2, 2, 4, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MaxPool2D(in[0], 2, 2, 0)
+		}},
+		{"MaxPool2D overlapping padded", []*autograd.Variable{normal(rng, 1, 2, 5, 6)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MaxPool2D(in[0], 3, 1, 1)
+		}},
+		{"AvgPool2D", []*autograd.Variable{normal(rng, 2, 2, 4, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.AvgPool2D(in[0], 2, 2, 0)
+		}},
+		{"AvgPool2D overlapping padded", []*autograd.Variable{normal(rng, 1, 2, 5, 6)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.AvgPool2D(in[0], 3, 2, 1)
+		}},
+		{"AdaptiveAvgPool2D", []*autograd.Variable{normal(rng, 2, 2, 5, 7)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.AdaptiveAvgPool2D(in[0], 3, 2)
+		}},
+		{"AdaptiveAvgPool2D global", []*autograd.Variable{normal(rng, 2, 3, 4, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.AdaptiveAvgPool2D(in[0], 1, 1)
+		}},
+		{"Im2Col1D", []*autograd.Variable{normal(rng, 2, 3, 7)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Im2Col1D(in[0], 3, 1, 0, 1)
+		}},
+		{"Im2Col1D stride padding dilation", []*autograd.Variable{normal(rng, 1, 2, 9)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Im2Col1D(in[0], 3, 2, 2, 2)
+		}},
+		{"MaxPool1D", []*autograd.Variable{normal(rng, 2, 2, 8)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MaxPool1D(in[0], 2, 2, 0)
+		}},
+		{"MaxPool1D overlapping padded", []*autograd.Variable{normal(rng, 1, 3, 7)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.MaxPool1D(in[0], 3, 1, 1)
+		}},
+		{"AvgPool1D", []*autograd.Variable{normal(rng, 2, 2, 8)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.AvgPool1D(in[0], 2, 2, 0)
+		}},
+		{"AvgPool1D overlapping padded", []*autograd.Variable{normal(rng, 1, 3, 7)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.AvgPool1D(in[0], 3, 2, 1)
+		}},
+		{"Col2Im", []*autograd.Variable{normal(rng, 12, 8)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Col2Im(in[0], 1, 2, 5, 4, 2, 2, 1, 0, 1)
+		}},
+		{"Col2Im stride padding dilation", []*autograd.Variable{normal(rng, 8, 18)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.Col2Im(in[0], 2, 2, 6, 5, 3, 3, 2, 1, 2)
+		}},
+		{"UpsampleNearest2D", []*autograd.Variable{normal(rng, 2, 2, 2, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.UpsampleNearest2D(in[0], 4, 6)
+		}},
+		{"UpsampleNearest2D uneven", []*autograd.Variable{normal(rng, 1, 2, 3, 3)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.UpsampleNearest2D(in[0], 5, 7)
+		}},
+		{"UpsampleBilinear2D", []*autograd.Variable{normal(rng, 2, 2, 3, 4)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.UpsampleBilinear2D(in[0], 6, 8)
+		}},
+		{"UpsampleBilinear2D downsample", []*autograd.Variable{normal(rng, 1, 2, 5, 5)}, func(t *autograd.Tape, in []*autograd.Variable) *autograd.Variable {
+			return t.UpsampleBilinear2D(in[0], 3, 2)
+		}},
+	})
+}
+
+// TestBackwardAccumulates checks that parameter gradients add up over backward passes
+// until they are cleared, while intermediate results start from zero every pass
+func TestBackwardAccumulates(t *testing.T) {
+	w := autograd.NewParameter("w", tensor.New(tensor.WithShape(3), tensor.WithBacking([]float64{1, -2, 3})))
+	tape := autograd.NewTape()
+	out := tape.Sum(tape.Square(tape.Scale(w.Var(), 2)))
+
+	// d/dw sum((2w)^2) = 8w
+	tape.Backward(out, nil)
+	tape.Backward(out, nil)
+	for i, g := range w.Grad.Data().([]float64) {
+		if want := 2 * 8 * w.Data()[i]; g != want {
+			t.Errorf("after two passes gradient[%d] = %v, want %v", i, g, want)
+		}
+	}
+
+	w.ZeroGrad()
+	tape.Backward(out, nil)
+	for i, g := range w.Grad.Data().([]float64) {
+		if want := 8 * w.Data()[i]; g != want {
+			t.Errorf("after clearing gradient[%d] = %v, want %v", i, g, want)
+		}
+	}
+}
+
+// TestPowAtZero checks the derivative of x^p at x = 0, where math.Pow(0, p-1) is infinite for p < 1
+func TestPowAtZero(t *testing.T) {
+	for _, c := range []struct{ p, want float64 }{{0, 0}, {1, 1}, {2, 0}, {3, 0}} {
+		x := variable([]float64{0}, 1)
+		tape := autograd.NewTape()
+		tape.Backward(tape.Pow(x, c.p), nil)
+		if got := x.Grad.Data().([]float64)[0]; got != c.want {
+			t.Errorf("d/dx x^%v at 0 = %v, want %v", c.p, got, c.want)
+		}
+	}
+}
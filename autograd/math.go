@@ -0,0 +1,191 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+)
+
+// unary records an element-wise function f whose derivative df is expressed
+// in terms of the input x and the output y
+func (t *Tape) unary(a *Variable, f func(x float64) float64, df func(x, y float64) float64) *Variable {
+	aData := a.Data()
+	data := make([]float64, len(aData))
+	for i, x := range aData {
+		data[i] = f(x)
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		gradA := make([]float64, len(grad))
+		for i, g := range grad {
+			gradA[i] = g * df(aData[i], data[i])
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+// Exp computes element-wise e^a
+func (t *Tape) Exp(a *Variable) *Variable {
+	return t.unary(a, math.Exp, func(x, y float64) float64 { return y })
+}
+
+// Log computes the element-wise natural logarithm of a
+func (t *Tape) Log(a *Variable) *Variable {
+	return t.unary(a, math.Log, func(x, y float64) float64 { return 1.0 / x })
+}
+
+// Square computes element-wise a^2
+func (t *Tape) Square(a *Variable) *Variable {
+	return t.unary(a, func(x float64) float64 { return x * x }, func(x, y float64) float64 { return 2 * x })
+}
+
+// Sqrt computes the element-wise square root of a
+func (t *Tape) Sqrt(a *Variable) *Variable {
+	return t.unary(a, math.Sqrt, func(x, y float64) float64 { return 0.5 / y })
+}
+
+// Pow raises every element of a to the constant power p
+func (t *Tape) Pow(a *Variable, p float64) *Variable {
+	return t.unary(a, func(x float64) float64 {
+		return math.Pow(x, p)
+	}, func(x, y float64) float64 {
+		// x^0 is constant; math.Pow(0, -1) would turn its zero derivative into 0 * Inf = NaN
+		if p == 0 {
+			return 0
+		}
+		return p * math.Pow(x, p-1)
+	})
+}
+
+// Tanh computes the element-wise hyperbolic tangent of a
+func (t *Tape) Tanh(a *Variable) *Variable {
+	return t.unary(a, math.Tanh, func(x, y float64) float64 { return 1 - y*y })
+}
+
+// Sigmoid computes element-wise 1 / (1 + exp(-a))
+func (t *Tape) Sigmoid(a *Variable) *Variable {
+	return t.unary(a, sigmoid, func(x, y float64) float64 { return y * (1 - y) })
+}
+
+// ReLU computes element-wise max(0, a)
+func (t *Tape) ReLU(a *Variable) *Variable {
+	return t.LeakyReLU(a, 0)
+}
+
+// LeakyReLU computes element-wise a if a > 0, else alpha * a
+func (t *Tape) LeakyReLU(a *Variable, alpha float64) *Variable {
+	return t.unary(a, func(x float64) float64 {
+		if x < 0 {
+			return alpha * x
+		}
+		return x
+	}, func(x, y float64) float64 {
+		if x > 0 {
+			return 1
+		}
+		return alpha
+	})
+}
+
+// SiLU computes element-wise a * sigmoid(a)
+func (t *Tape) SiLU(a *Variable) *Variable {
+	return t.unary(a, func(x float64) float64 {
+		return x * sigmoid(x)
+	}, func(x, y float64) float64 {
+		s := sigmoid(x)
+		return s + x*s*(1-s)
+	})
+}
+
+// Softmax normalises the last dimension of a into probabilities
+func (t *Tape) Softmax(a *Variable) *Variable {
+	aData := a.Data()
+	cols := lastDim(a)
+	data := make([]float64, len(aData))
+	for start := 0; start < len(aData); start += cols {
+		softmaxRow(aData[start:start+cols], data[start:start+cols])
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		gradA := make([]float64, len(grad))
+		for start := 0; start < len(grad); start += cols {
+			dot := 0.0
+			for j := start; j < start+cols; j++ {
+				dot += grad[j] * data[j]
+			}
+			for j := start; j < start+cols; j++ {
+				gradA[j] = data[j] * (grad[j] - dot)
+			}
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+// LogSoftmax computes log(softmax(a)) over the last dimension in a numerically stable way
+func (t *Tape) LogSoftmax(a *Variable) *Variable {
+	aData := a.Data()
+	cols := lastDim(a)
+	data := make([]float64, len(aData))
+	for start := 0; start < len(aData); start += cols {
+		logSoftmaxRow(aData[start:start+cols], data[start:start+cols])
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		gradA := make([]float64, len(grad))
+		for start := 0; start < len(grad); start += cols {
+			sum := 0.0
+			for j := start; j < start+cols; j++ {
+				sum += grad[j]
+			}
+			for j := start; j < start+cols; j++ {
+				gradA[j] = grad[j] - math.Exp(data[j])*sum
+			}
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+func sigmoid(x float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-x))
+}
+
+// softmaxRow writes the softmax of in to out, subtracting the max for stability
+func softmaxRow(in, out []float64) {
+	maxVal := in[0]
+	for _, v := range in[1:] {
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+	sum := 0.0
+	for j, v := range in {
+		out[j] = math.Exp(v - maxVal)
+		sum += out[j]
+	}
+	for j := range out {
+		out[j] /= sum
+	}
+}
+
+// logSoftmaxRow writes the log-softmax of in to out using the log-sum-exp trick
+func logSoftmaxRow(in, out []float64) {
+	maxVal := in[0]
+	for _, v := range in[1:] {
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+	sum := 0.0
+	for _, v := range in {
+		sum += math.Exp(v - maxVal)
+	}
+	logSum := maxVal + math.Log(sum)
+	for j, v := range in {
+		out[j] = v - logSum
+	}
+}
+
+// lastDim returns the size of the last dimension of a
+func lastDim(a *Variable) int {
+	shape := a.Shape()
+	if len(shape) == 0 {
+		panic(fmt.Sprintf("autograd: expected at least one dimension, got shape %v", shape))
+	}
+	return shape[len(shape)-1]
+}
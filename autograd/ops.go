@@ -0,0 +1,467 @@
+package autograd
+
+import (
+	"fmt"
+
+	"gorgonia.org/tensor"
+)
+
+// Add computes element-wise a + b
+func (t *Tape) Add(a, b *Variable) *Variable {
+	checkSameSize("Add", a, b)
+	aData, bData := a.Data(), b.Data()
+	data := make([]float64, len(aData))
+	for i := range data {
+		data[i] = aData[i] + bData[i]
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		a.accumulate(grad)
+		b.accumulate(grad)
+	}, a, b)
+}
+
+// Sub computes element-wise a - b
+func (t *Tape) Sub(a, b *Variable) *Variable {
+	checkSameSize("Sub", a, b)
+	aData, bData := a.Data(), b.Data()
+	data := make([]float64, len(aData))
+	for i := range data {
+		data[i] = aData[i] - bData[i]
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		a.accumulate(grad)
+		b.accumulate(scaled(grad, -1))
+	}, a, b)
+}
+
+// Mul computes element-wise a * b
+func (t *Tape) Mul(a, b *Variable) *Variable {
+	checkSameSize("Mul", a, b)
+	aData, bData := a.Data(), b.Data()
+	data := make([]float64, len(aData))
+	for i := range data {
+		data[i] = aData[i] * bData[i]
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		if a.RequiresGrad {
+			gradA := make([]float64, len(grad))
+			for i, g := range grad {
+				gradA[i] = g * bData[i]
+			}
+			a.accumulate(gradA)
+		}
+		if b.RequiresGrad {
+			gradB := make([]float64, len(grad))
+			for i, g := range grad {
+				gradB[i] = g * aData[i]
+			}
+			b.accumulate(gradB)
+		}
+	}, a, b)
+}
+
+// Div computes element-wise a / b
+func (t *Tape) Div(a, b *Variable) *Variable {
+	checkSameSize("Div", a, b)
+	aData, bData := a.Data(), b.Data()
+	data := make([]float64, len(aData))
+	for i := range data {
+		data[i] = aData[i] / bData[i]
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		if a.RequiresGrad {
+			gradA := make([]float64, len(grad))
+			for i, g := range grad {
+				gradA[i] = g / bData[i]
+			}
+			a.accumulate(gradA)
+		}
+		if b.RequiresGrad {
+			gradB := make([]float64, len(grad))
+			for i, g := range grad {
+				gradB[i] = -g * data[i] / bData[i]
+			}
+			b.accumulate(gradB)
+		}
+	}, a, b)
+}
+
+// AddRow adds a row vector to every row of x, broadcasting over all leading dimensions
+func (t *Tape) AddRow(x, row *Variable) *Variable {
+	xData, rowData := x.Data(), row.Data()
+	cols := checkRow("AddRow", x, row)
+	data := make([]float64, len(xData))
+	for i := range data {
+		data[i] = xData[i] + rowData[i%cols]
+	}
+	return t.record(x.Shape(), data, func(grad []float64) {
+		x.accumulate(grad)
+		if row.RequiresGrad {
+			gradRow := make([]float64, cols)
+			for i, g := range grad {
+				gradRow[i%cols] += g
+			}
+			row.accumulate(gradRow)
+		}
+	}, x, row)
+}
+
+// MulRow multiplies every row of x element-wise by a row vector
+func (t *Tape) MulRow(x, row *Variable) *Variable {
+	xData, rowData := x.Data(), row.Data()
+	cols := checkRow("MulRow", x, row)
+	data := make([]float64, len(xData))
+	for i := range data {
+		data[i] = xData[i] * rowData[i%cols]
+	}
+	return t.record(x.Shape(), data, func(grad []float64) {
+		if x.RequiresGrad {
+			gradX := make([]float64, len(grad))
+			for i, g := range grad {
+				gradX[i] = g * rowData[i%cols]
+			}
+			x.accumulate(gradX)
+		}
+		if row.RequiresGrad {
+			gradRow := make([]float64, cols)
+			for i, g := range grad {
+				gradRow[i%cols] += g * xData[i]
+			}
+			row.accumulate(gradRow)
+		}
+	}, x, row)
+}
+
+// Scale multiplies every element of a by the constant c
+func (t *Tape) Scale(a *Variable, c float64) *Variable {
+	return t.record(a.Shape(), scaled(a.Data(), c), func(grad []float64) {
+		a.accumulate(scaled(grad, c))
+	}, a)
+}
+
+// AddScalar adds the constant c to every element of a
+func (t *Tape) AddScalar(a *Variable, c float64) *Variable {
+	aData := a.Data()
+	data := make([]float64, len(aData))
+	for i, v := range aData {
+		data[i] = v + c
+	}
+	return t.record(a.Shape(), data, func(grad []float64) {
+		a.accumulate(grad)
+	}, a)
+}
+
+// Neg computes element-wise -a
+func (t *Tape) Neg(a *Variable) *Variable {
+	return t.Scale(a, -1)
+}
+
+// MatMul computes the matrix product of two 2D variables
+func (t *Tape) MatMul(a, b *Variable) *Variable {
+	aShape, bShape := a.Shape(), b.Shape()
+	if len(aShape) != 2 || len(bShape) != 2 || aShape[1] != bShape[0] {
+		panic(fmt.Sprintf("autograd: MatMul shape mismatch %v x %v", aShape, bShape))
+	}
+	aValue, bValue := a.Value, b.Value
+	result := matMul(aValue, bValue)
+	return t.record(result.Shape(), result.Data().([]float64), func(grad []float64) {
+		gradMat := newDense([]int{aShape[0], bShape[1]}, grad)
+		if a.RequiresGrad {
+			a.accumulate(matMul(gradMat, transpose(bValue)).Data().([]float64))
+		}
+		if b.RequiresGrad {
+			b.accumulate(matMul(transpose(aValue), gradMat).Data().([]float64))
+		}
+	}, a, b)
+}
+
+// BatchMatMul multiplies matching matrices of a (N, M, K) and b (N, K, P) into (N, M, P)
+func (t *Tape) BatchMatMul(a, b *Variable) *Variable {
+	aShape, bShape := a.Shape(), b.Shape()
+	if len(aShape) != 3 || len(bShape) != 3 || aShape[0] != bShape[0] || aShape[2] != bShape[1] {
+		panic(fmt.Sprintf("autograd: BatchMatMul shape mismatch %v x %v", aShape, bShape))
+	}
+	n, m, k, p := aShape[0], aShape[1], aShape[2], bShape[2]
+	aData, bData := a.Data(), b.Data()
+	data := make([]float64, n*m*p)
+	for batch := 0; batch < n; batch++ {
+		aOff, bOff, outOff := batch*m*k, batch*k*p, batch*m*p
+		for i := 0; i < m; i++ {
+			for l := 0; l < k; l++ {
+				av := aData[aOff+i*k+l]
+				for j := 0; j < p; j++ {
+					data[outOff+i*p+j] += av * bData[bOff+l*p+j]
+				}
+			}
+		}
+	}
+	return t.record([]int{n, m, p}, data, func(grad []float64) {
+		gradA := make([]float64, len(aData))
+		gradB := make([]float64, len(bData))
+		for batch := 0; batch < n; batch++ {
+			aOff, bOff, outOff := batch*m*k, batch*k*p, batch*m*p
+			for i := 0; i < m; i++ {
+				for l := 0; l < k; l++ {
+					av := aData[aOff+i*k+l]
+					sum := 0.0
+					for j := 0; j < p; j++ {
+						g := grad[outOff+i*p+j]
+						sum += g * bData[bOff+l*p+j]
+						gradB[bOff+l*p+j] += av * g
+					}
+					gradA[aOff+i*k+l] += sum
+				}
+			}
+		}
+		a.accumulate(gradA)
+		b.accumulate(gradB)
+	}, a, b)
+}
+
+// Transpose swaps the two axes of a 2D variable
+func (t *Tape) Transpose(a *Variable) *Variable {
+	shape := a.Shape()
+	if len(shape) != 2 {
+		panic(fmt.Sprintf("autograd: Transpose expects a 2D variable, got shape %v", shape))
+	}
+	result := transpose(a.Value)
+	return t.record(result.Shape(), result.Data().([]float64), func(grad []float64) {
+		a.accumulate(transpose(newDense([]int{shape[1], shape[0]}, grad)).Data().([]float64))
+	}, a)
+}
+
+// Reshape returns a copy of a with a new shape holding the same number of elements
+func (t *Tape) Reshape(a *Variable, shape ...int) *Variable {
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	aData := a.Data()
+	if size != len(aData) {
+		panic(fmt.Sprintf("autograd: cannot reshape %v into %v", a.Shape(), shape))
+	}
+	data := make([]float64, len(aData))
+	copy(data, aData)
+	return t.record(shape, data, func(grad []float64) {
+		a.accumulate(grad)
+	}, a)
+}
+
+// Permute reorders the axes of a so that output axis i is input axis axes[i]
+func (t *Tape) Permute(a *Variable, axes ...int) *Variable {
+	inShape := a.Shape()
+	if len(axes) != len(inShape) {
+		panic(fmt.Sprintf("autograd: Permute expects %d axes for shape %v, got %v", len(inShape), inShape, axes))
+	}
+	outShape := make([]int, len(axes))
+	for i, axis := range axes {
+		outShape[i] = inShape[axis]
+	}
+
+	// index[i] is the position in a of output element i
+	inStrides := strides(inShape)
+	outStrides := strides(outShape)
+	aData := a.Data()
+	index := make([]int, len(aData))
+	data := make([]float64, len(aData))
+	for i := range data {
+		rem, src := i, 0
+		for d, axis := range axes {
+			src += (rem / outStrides[d]) * inStrides[axis]
+			rem %= outStrides[d]
+		}
+		index[i] = src
+		data[i] = aData[src]
+	}
+	return t.record(outShape, data, func(grad []float64) {
+		gradA := make([]float64, len(grad))
+		for i, g := range grad {
+			gradA[index[i]] += g
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+// Narrow returns the slice [start, start+length) of a along axis
+func (t *Tape) Narrow(a *Variable, axis, start, length int) *Variable {
+	shape := a.Shape()
+	if axis < 0 || axis >= len(shape) || start < 0 || length <= 0 || start+length > shape[axis] {
+		panic(fmt.Sprintf("autograd: cannot narrow shape %v to [%d, %d) along axis %d", shape, start, start+length, axis))
+	}
+	outer, inner := splitAt(shape, axis)
+	outShape := append([]int(nil), shape...)
+	outShape[axis] = length
+
+	aData := a.Data()
+	data := make([]float64, outer*length*inner)
+	for o := 0; o < outer; o++ {
+		src := (o*shape[axis] + start) * inner
+		copy(data[o*length*inner:(o+1)*length*inner], aData[src:src+length*inner])
+	}
+	return t.record(outShape, data, func(grad []float64) {
+		gradA := make([]float64, len(aData))
+		for o := 0; o < outer; o++ {
+			dst := (o*shape[axis] + start) * inner
+			copy(gradA[dst:dst+length*inner], grad[o*length*inner:(o+1)*length*inner])
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+// Concat joins variables along axis; all other dimensions must match
+func (t *Tape) Concat(axis int, vars ...*Variable) *Variable {
+	if len(vars) == 0 {
+		panic("autograd: Concat needs at least one variable")
+	}
+	first := vars[0].Shape()
+	if axis < 0 || axis >= len(first) {
+		panic(fmt.Sprintf("autograd: Concat axis %d out of range for shape %v", axis, first))
+	}
+	outShape := append([]int(nil), first...)
+	outShape[axis] = 0
+	for _, v := range vars {
+		shape := v.Shape()
+		for d := range shape {
+			if len(shape) != len(first) || (d != axis && shape[d] != first[d]) {
+				panic(fmt.Sprintf("autograd: Concat shape mismatch %v vs %v along axis %d", first, shape, axis))
+			}
+		}
+		outShape[axis] += shape[axis]
+	}
+
+	outer, inner := splitAt(outShape, axis)
+	data := make([]float64, outer*outShape[axis]*inner)
+	offset := 0
+	for _, v := range vars {
+		width := v.Shape()[axis] * inner
+		vData := v.Data()
+		for o := 0; o < outer; o++ {
+			dst := o*outShape[axis]*inner + offset
+			copy(data[dst:dst+width], vData[o*width:(o+1)*width])
+		}
+		offset += width
+	}
+	return t.record(outShape, data, func(grad []float64) {
+		offset := 0
+		for _, v := range vars {
+			width := v.Shape()[axis] * inner
+			if v.RequiresGrad {
+				gradV := make([]float64, outer*width)
+				for o := 0; o < outer; o++ {
+					src := o*outShape[axis]*inner + offset
+					copy(gradV[o*width:(o+1)*width], grad[src:src+width])
+				}
+				v.accumulate(gradV)
+			}
+			offset += width
+		}
+	}, vars...)
+}
+
+// Sum reduces all elements of a to a single-element variable
+func (t *Tape) Sum(a *Variable) *Variable {
+	aData := a.Data()
+	total := 0.0
+	for _, v := range aData {
+		total += v
+	}
+	return t.record([]int{1}, []float64{total}, func(grad []float64) {
+		gradA := make([]float64, len(aData))
+		for i := range gradA {
+			gradA[i] = grad[0]
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+// SumRows sums a over all leading dimensions, producing one value per column of the last dimension
+func (t *Tape) SumRows(a *Variable) *Variable {
+	aData := a.Data()
+	cols := lastDim(a)
+	data := make([]float64, cols)
+	for i, v := range aData {
+		data[i%cols] += v
+	}
+	return t.record([]int{cols}, data, func(grad []float64) {
+		gradA := make([]float64, len(aData))
+		for i := range gradA {
+			gradA[i] = grad[i%cols]
+		}
+		a.accumulate(gradA)
+	}, a)
+}
+
+// MeanRows averages a over all leading dimensions, producing one value per column of the last dimension
+func (t *Tape) MeanRows(a *Variable) *Variable {
+	return t.Scale(t.SumRows(a), float64(lastDim(a))/float64(len(a.Data())))
+}
+
+// Mean reduces all elements of a to their average
+func (t *Tape) Mean(a *Variable) *Variable {
+	return t.Scale(t.Sum(a), 1.0/float64(len(a.Data())))
+}
+
+// checkSameSize panics if a and b do not hold the same number of elements
+func checkSameSize(op string, a, b *Variable) {
+	if len(a.Data()) != len(b.Data()) {
+		panic(fmt.Sprintf("autograd: %s shape mismatch %v vs %v", op, a.Shape(), b.Shape()))
+	}
+}
+
+// checkRow validates that row matches the last dimension of x and returns its length
+func checkRow(op string, x, row *Variable) int {
+	shape := x.Shape()
+	cols := shape[len(shape)-1]
+	if len(row.Data()) != cols {
+		panic(fmt.Sprintf("autograd: %s expects a row of %d elements, got shape %v", op, cols, row.Shape()))
+	}
+	return cols
+}
+
+// splitAt returns the number of elements before and after axis in row-major order
+func splitAt(shape []int, axis int) (outer, inner int) {
+	outer, inner = 1, 1
+	for d := 0; d < axis; d++ {
+		outer *= shape[d]
+	}
+	for d := axis + 1; d < len(shape); d++ {
+		inner *= shape[d]
+	}
+	return outer, inner
+}
+
+// strides returns the row-major strides of shape
+func strides(shape []int) []int {
+	result := make([]int, len(shape))
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		result[i] = stride
+		stride *= shape[i]
+	}
+	return result
+}
+
+// scaled returns a new slice holding every element of data multiplied by c
+func scaled(data []float64, c float64) []float64 {
+	result := make([]float64, len(data))
+	for i, v := range data {
+		result[i] = v * c
+	}
+	return result
+}
+
+func matMul(a, b *tensor.Dense) *tensor.Dense {
+	result, err := tensor.MatMul(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return result.(*tensor.Dense)
+}
+
+func transpose(a *tensor.Dense) *tensor.Dense {
+	result, err := tensor.Transpose(a)
+	if err != nil {
+		panic(err)
+	}
+	return result.(*tensor.Dense)
+}
@@ -0,0 +1,27 @@
+package autograd
+
+import "gorgonia.org/tensor"
+
+// Parameter is a named variable owned by a layer and updated by an optimizer.
+// Parameters with RequiresGrad set to false (e.g. running statistics) are
+// persisted with the model but skipped by optimizers.
+type Parameter struct {
+	Variable
+	Name string // identifies the parameter within its layer
+}
+
+// NewParameter creates a learnable parameter with the given name and initial value
+func NewParameter(name string, value *tensor.Dense) *Parameter {
+	return &Parameter{
+		Variable: Variable{
+			Value:        value,
+			RequiresGrad: true,
+		},
+		Name: name,
+	}
+}
+
+// Var returns the parameter as a variable for use in tape operations
+func (p *Parameter) Var() *Variable {
+	return &p.Variable
+}
@@ -0,0 +1,188 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxPool2D takes the maximum of every kernel x kernel window of an NCHW input.
+// The position of each maximum is cached so the gradient flows only to it.
+func (t *Tape) MaxPool2D(x *Variable, kernel, stride, padding int) *Variable {
+	checkNCHW("MaxPool2D", x)
+	if padding > kernel/2 {
+		panic(fmt.Sprintf("autograd: MaxPool2D padding %d must be at most half the kernel size %d", padding, kernel))
+	}
+	return t.maxPool("MaxPool2D", x, [2]int{kernel, kernel}, [2]int{stride, stride}, [2]int{padding, padding})
+}
+
+// MaxPool1D takes the maximum of every window of an (N, C, L) input
+func (t *Tape) MaxPool1D(x *Variable, kernel, stride, padding int) *Variable {
+	n, c, l := checkNCL("MaxPool1D", x)
+	if padding > kernel/2 {
+		panic(fmt.Sprintf("autograd: MaxPool1D padding %d must be at most half the kernel size %d", padding, kernel))
+	}
+	out := t.maxPool("MaxPool1D", t.Reshape(x, n, c, 1, l), [2]int{1, kernel}, [2]int{1, stride}, [2]int{0, padding})
+	return t.Reshape(out, n, c, out.Shape()[3])
+}
+
+// maxPool records a max pooling of an NCHW input with per-axis {height, width} window settings
+func (t *Tape) maxPool(op string, x *Variable, kernel, stride, padding [2]int) *Variable {
+	n, c, h, w := checkNCHW(op, x)
+	outH := ConvOutputSize(h, kernel[0], stride[0], padding[0], 1)
+	outW := ConvOutputSize(w, kernel[1], stride[1], padding[1], 1)
+	checkWindow(op, outH, outW, kernel[1], h, w)
+
+	xData := x.Data()
+	argmax := make([]int, n*c*outH*outW)
+	data := make([]float64, len(argmax))
+	i := 0
+	for plane := 0; plane < n*c; plane++ {
+		offset := plane * h * w
+		for oh := 0; oh < outH; oh++ {
+			for ow := 0; ow < outW; ow++ {
+				best, bestIdx := math.Inf(-1), -1
+				for kh := 0; kh < kernel[0]; kh++ {
+					ih := oh*stride[0] - padding[0] + kh
+					if ih < 0 || ih >= h {
+						continue
+					}
+					for kw := 0; kw < kernel[1]; kw++ {
+						iw := ow*stride[1] - padding[1] + kw
+						if iw < 0 || iw >= w {
+							continue
+						}
+						idx := offset + ih*w + iw
+						if bestIdx < 0 || xData[idx] > best {
+							best, bestIdx = xData[idx], idx
+						}
+					}
+				}
+				argmax[i] = bestIdx
+				data[i] = best
+				i++
+			}
+		}
+	}
+
+	return t.record([]int{n, c, outH, outW}, data, func(grad []float64) {
+		gradX := make([]float64, len(xData))
+		for i, g := range grad {
+			gradX[argmax[i]] += g
+		}
+		x.accumulate(gradX)
+	}, x)
+}
+
+// AvgPool2D averages every kernel x kernel window of an NCHW input.
+// Zero padding is counted in the average, and the gradient is spread evenly over each window.
+func (t *Tape) AvgPool2D(x *Variable, kernel, stride, padding int) *Variable {
+	n, c, h, w := checkNCHW("AvgPool2D", x)
+	outH := ConvOutputSize(h, kernel, stride, padding, 1)
+	outW := ConvOutputSize(w, kernel, stride, padding, 1)
+	checkWindow("AvgPool2D", outH, outW, kernel, h, w)
+
+	area := float64(kernel * kernel)
+	return t.windowAverage(x, n, c, h, w, outH, outW, func(oh, ow int) (int, int, int, int, float64) {
+		h0, w0 := oh*stride-padding, ow*stride-padding
+		return h0, h0 + kernel, w0, w0 + kernel, area
+	})
+}
+
+// AvgPool1D averages every window of an (N, C, L) input, counting zero padding in the average
+func (t *Tape) AvgPool1D(x *Variable, kernel, stride, padding int) *Variable {
+	n, c, l := checkNCL("AvgPool1D", x)
+	outL := ConvOutputSize(l, kernel, stride, padding, 1)
+	checkWindow("AvgPool1D", 1, outL, kernel, 1, l)
+
+	out := t.windowAverage(x, n, c, 1, l, 1, outL, func(_, ol int) (int, int, int, int, float64) {
+		l0 := ol*stride - padding
+		return 0, 1, l0, l0 + kernel, float64(kernel)
+	})
+	return t.Reshape(out, n, c, outL)
+}
+
+// AdaptiveAvgPool2D averages an NCHW input down to a fixed outH x outW grid,
+// choosing window boundaries from the input size so any resolution is accepted
+func (t *Tape) AdaptiveAvgPool2D(x *Variable, outH, outW int) *Variable {
+	n, c, h, w := checkNCHW("AdaptiveAvgPool2D", x)
+	if outH <= 0 || outW <= 0 {
+		panic(fmt.Sprintf("autograd: AdaptiveAvgPool2D output size %dx%d must be positive", outH, outW))
+	}
+
+	return t.windowAverage(x, n, c, h, w, outH, outW, func(oh, ow int) (int, int, int, int, float64) {
+		h0, h1 := oh*h/outH, ((oh+1)*h+outH-1)/outH
+		w0, w1 := ow*w/outW, ((ow+1)*w+outW-1)/outW
+		return h0, h1, w0, w1, float64((h1 - h0) * (w1 - w0))
+	})
+}
+
+// windowAverage records an average over the half-open window [h0, h1) x [w0, w1)
+// returned by window for every output position; out-of-range positions count as zeros
+func (t *Tape) windowAverage(x *Variable, n, c, h, w, outH, outW int,
+	window func(oh, ow int) (h0, h1, w0, w1 int, count float64)) *Variable {
+	xData := x.Data()
+	data := make([]float64, n*c*outH*outW)
+	i := 0
+	for plane := 0; plane < n*c; plane++ {
+		offset := plane * h * w
+		for oh := 0; oh < outH; oh++ {
+			for ow := 0; ow < outW; ow++ {
+				h0, h1, w0, w1, count := window(oh, ow)
+				sum := 0.0
+				for ih := max(h0, 0); ih < min(h1, h); ih++ {
+					for iw := max(w0, 0); iw < min(w1, w); iw++ {
+						sum += xData[offset+ih*w+iw]
+					}
+				}
+				data[i] = sum / count
+				i++
+			}
+		}
+	}
+
+	return t.record([]int{n, c, outH, outW}, data, func(grad []float64) {
+		gradX := make([]float64, len(xData))
+		i := 0
+		for plane := 0; plane < n*c; plane++ {
+			offset := plane * h * w
+			for oh := 0; oh < outH; oh++ {
+				for ow := 0; ow < outW; ow++ {
+					h0, h1, w0, w1, count := window(oh, ow)
+					g := grad[i] / count
+					for ih := max(h0, 0); ih < min(h1, h); ih++ {
+						for iw := max(w0, 0); iw < min(w1, w); iw++ {
+							gradX[offset+ih*w+iw] += g
+						}
+					}
+					i++
+				}
+			}
+		}
+		x.accumulate(gradX)
+	}, x)
+}
+
+// checkNCHW validates a 4D input and returns its dimensions
+func checkNCHW(op string, x *Variable) (n, c, h, w int) {
+	shape := x.Shape()
+	if len(shape) != 4 {
+		panic(fmt.Sprintf("autograd: %s expects NCHW input, got shape %v", op, shape))
+	}
+	return shape[0], shape[1], shape[2], shape[3]
+}
+
+// checkNCL validates a 3D input and returns its dimensions
+func checkNCL(op string, x *Variable) (n, c, l int) {
+	shape := x.Shape()
+	if len(shape) != 3 {
+		panic(fmt.Sprintf("autograd: %s expects (N, C, L) input, got shape %v", op, shape))
+	}
+	return shape[0], shape[1], shape[2]
+}
+
+// checkWindow panics if a pooling window does not fit the input
+func checkWindow(op string, outH, outW, kernel, h, w int) {
+	if outH <= 0 || outW <= 0 {
+		panic(fmt.Sprintf("autograd: %s kernel %d does not fit input %dx%d", op, kernel, h, w))
+	}
+}
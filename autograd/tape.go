@@ -0,0 +1,85 @@
+package autograd
+
+import (
+	"fmt"
+
+	"gorgonia.org/tensor"
+)
+
+// record stores one operation on the tape together with its vector-Jacobian product
+type record struct {
+	output   *Variable            // variable produced by the operation
+	backward func(grad []float64) // propagates the output gradient to the inputs
+}
+
+// Tape records differentiable operations in execution order for reverse-mode differentiation
+type Tape struct {
+	records []record
+}
+
+// NewTape creates an empty tape
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+// Len returns the number of recorded operations
+func (t *Tape) Len() int {
+	return len(t.records)
+}
+
+// Reset discards all recorded operations
+func (t *Tape) Reset() {
+	t.records = nil
+}
+
+// Backward propagates seed from out back through every recorded operation.
+// A nil seed is treated as a tensor of ones, which is the usual choice for scalar losses.
+// Gradients are accumulated into the Grad of every leaf variable that requires them.
+func (t *Tape) Backward(out *Variable, seed *tensor.Dense) {
+	if !out.RequiresGrad {
+		return
+	}
+
+	// Intermediate gradients from a previous pass must not leak into this one
+	for _, r := range t.records {
+		r.output.Grad = nil
+	}
+
+	var seedData []float64
+	if seed == nil {
+		seedData = make([]float64, len(out.Data()))
+		for i := range seedData {
+			seedData[i] = 1.0
+		}
+	} else {
+		seedData = seed.Data().([]float64)
+		if len(seedData) != len(out.Data()) {
+			panic(fmt.Sprintf("autograd: seed shape %v does not match output shape %v", seed.Shape(), out.Shape()))
+		}
+	}
+	out.accumulate(seedData)
+
+	for i := len(t.records) - 1; i >= 0; i-- {
+		r := t.records[i]
+		if r.output.Grad == nil {
+			continue
+		}
+		r.backward(r.output.Grad.Data().([]float64))
+	}
+}
+
+// record creates the output variable of an operation and, if any input requires
+// gradients, appends the operation's backward function to the tape
+func (t *Tape) record(shape []int, data []float64, backward func(grad []float64), inputs ...*Variable) *Variable {
+	out := &Variable{Value: newDense(shape, data)}
+	for _, in := range inputs {
+		if in.RequiresGrad {
+			out.RequiresGrad = true
+			break
+		}
+	}
+	if out.RequiresGrad {
+		t.records = append(t.records, record{output: out, backward: backward})
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+)
+
+// UpsampleNearest2D resizes an NCHW input to outH x outW by copying the nearest input pixel
+func (t *Tape) UpsampleNearest2D(x *Variable, outH, outW int) *Variable {
+	return t.interpolate("UpsampleNearest2D", x, outH, outW, func(dst, in, out int) []tap {
+		src := min(dst*in/out, in-1)
+		return []tap{{src, 1}}
+	})
+}
+
+// UpsampleBilinear2D resizes an NCHW input to outH x outW by bilinear interpolation.
+// Pixel centres are aligned as in PyTorch's align_corners=false.
+func (t *Tape) UpsampleBilinear2D(x *Variable, outH, outW int) *Variable {
+	return t.interpolate("UpsampleBilinear2D", x, outH, outW, func(dst, in, out int) []tap {
+		src := math.Max((float64(dst)+0.5)*float64(in)/float64(out)-0.5, 0)
+		lo := int(src)
+		hi := min(lo+1, in-1)
+		frac := src - float64(lo)
+		return []tap{{lo, 1 - frac}, {hi, frac}}
+	})
+}
+
+// tap is one weighted input position contributing to an interpolated output position
+type tap struct {
+	index  int
+	weight float64
+}
+
+// interpolate records a separable resize of an NCHW input where taps returns the
+// weighted input rows (or columns) contributing to output row (or column) dst
+func (t *Tape) interpolate(op string, x *Variable, outH, outW int, taps func(dst, in, out int) []tap) *Variable {
+	n, c, h, w := checkNCHW(op, x)
+	if outH <= 0 || outW <= 0 {
+		panic(fmt.Sprintf("autograd: %s output size %dx%d must be positive", op, outH, outW))
+	}
+	rowTaps := make([][]tap, outH)
+	for oh := range rowTaps {
+		rowTaps[oh] = taps(oh, h, outH)
+	}
+	colTaps := make([][]tap, outW)
+	for ow := range colTaps {
+		colTaps[ow] = taps(ow, w, outW)
+	}
+
+	xData := x.Data()
+	data := make([]float64, n*c*outH*outW)
+	i := 0
+	for plane := 0; plane < n*c; plane++ {
+		offset := plane * h * w
+		for oh := 0; oh < outH; oh++ {
+			for ow := 0; ow < outW; ow++ {
+				for _, r := range rowTaps[oh] {
+					for _, col := range colTaps[ow] {
+						data[i] += r.weight * col.weight * xData[offset+r.index*w+col.index]
+					}
+				}
+				i++
+			}
+		}
+	}
+
+	return t.record([]int{n, c, outH, outW}, data, func(grad []float64) {
+		gradX := make([]float64, len(xData))
+		i := 0
+		for plane := 0; plane < n*c; plane++ {
+			offset := plane * h * w
+			for oh := 0; oh < outH; oh++ {
+				for ow := 0; ow < outW; ow++ {
+					for _, r := range rowTaps[oh] {
+						for _, col := range colTaps[ow] {
+							gradX[offset+r.index*w+col.index] += r.weight * col.weight * grad[i]
+						}
+					}
+					i++
+				}
+			}
+		}
+		x.accumulate(gradX)
+	}, x)
+}
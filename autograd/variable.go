@@ -0,0 +1,74 @@
+package autograd
+
+import "gorgonia.org/tensor"
+
+// Variable is a differentiable tensor whose gradient is filled in by Tape.Backward
+type Variable struct {
+	Value        *tensor.Dense // current value
+	Grad         *tensor.Dense // accumulated gradient (nil until backward reaches it)
+	RequiresGrad bool          // whether gradients should flow into this variable
+}
+
+// NewVariable creates a leaf variable wrapping the given tensor
+func NewVariable(value *tensor.Dense, requiresGrad bool) *Variable {
+	return &Variable{
+		Value:        value,
+		RequiresGrad: requiresGrad,
+	}
+}
+
+// Shape returns the shape of the variable's value
+func (v *Variable) Shape() tensor.Shape {
+	return v.Value.Shape()
+}
+
+// Data returns the flat backing slice of the variable's value
+func (v *Variable) Data() []float64 {
+	return v.Value.Data().([]float64)
+}
+
+// ZeroGrad clears the accumulated gradient. Like PyTorch's set-to-None behaviour, a variable
+// that takes no part in the next backward pass is left without a gradient, so optimizers skip it
+// instead of moving it with momentum or weight decay.
+func (v *Variable) ZeroGrad() {
+	v.Grad = nil
+}
+
+// accumulate adds grad into the variable's gradient, allocating it on first use
+func (v *Variable) accumulate(grad []float64) {
+	if !v.RequiresGrad {
+		return
+	}
+	if v.Grad == nil {
+		v.Grad = newDense(v.Shape(), make([]float64, len(grad)))
+	}
+	gradData := v.Grad.Data().([]float64)
+	for i, g := range grad {
+		gradData[i] += g
+	}
+}
+
+// accumulateRows adds grad, laid out as consecutive rows of width cols,
+// into the listed rows of the variable's gradient without touching any other row
+func (v *Variable) accumulateRows(rows []int, cols int, grad []float64) {
+	if !v.RequiresGrad {
+		return
+	}
+	if v.Grad == nil {
+		v.Grad = newDense(v.Shape(), make([]float64, len(v.Data())))
+	}
+	gradData := v.Grad.Data().([]float64)
+	for i, row := range rows {
+		dst := gradData[row*cols : (row+1)*cols]
+		for j, g := range grad[i*cols : (i+1)*cols] {
+			dst[j] += g
+		}
+	}
+}
+
+// newDense wraps a backing slice in a tensor of the given shape
+func newDense(shape []int, data []float64) *tensor.Dense {
+	s := make([]int, len(shape))
+	copy(s, shape)
+	return tensor.New(tensor.WithShape(s...), tensor.WithBacking(data))
+}
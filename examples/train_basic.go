@@ -56,6 +56,7 @@ func main() {
 			model.GetOptimizer().ZeroGrad()
 			grad := criterion.Backward()
 			model.Backward(grad)
+			model.GetOptimizer().Step()
 
 			// Accumulate metrics
 			runningLoss += lossVal
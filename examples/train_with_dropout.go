@@ -58,6 +58,7 @@ package main
 // 			model.GetOptimizer().ZeroGrad()
 // 			grad := criterion.Backward()
 // 			model.Backward(grad)
+// 			model.GetOptimizer().Step()
 
 // 			// Accumulate metrics
 // 			runningLoss += lossVal
@@ -0,0 +1,182 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// maskedScore is added to attention scores that must be ignored; it is finite
+// so that a fully masked row yields a uniform distribution instead of NaN
+const maskedScore = -1e9
+
+// MultiHeadAttention applies scaled dot-product self-attention over (batch, seq, embedDim) input.
+// The embedding is split into NumHeads heads that attend independently before being
+// concatenated and projected back to embedDim.
+type MultiHeadAttention struct {
+	*Module
+	EmbedDim  int     // size of each token embedding
+	NumHeads  int     // number of attention heads, must divide EmbedDim
+	Causal    bool    // whether each position may only attend to itself and earlier positions
+	Query     *Linear // query projection (embedDim, embedDim)
+	Key       *Linear // key projection (embedDim, embedDim)
+	Value     *Linear // value projection (embedDim, embedDim)
+	Output    *Linear // output projection (embedDim, embedDim)
+	keyMask   []bool  // padded key positions (batch*seq), nil when every position is valid
+	maskShape []int   // (batch, seq) of keyMask
+}
+
+// NewMultiHeadAttention creates a self-attention layer with numHeads heads
+func NewMultiHeadAttention(embedDim, numHeads int, causal bool) *MultiHeadAttention {
+	return newMultiHeadAttention("", embedDim, numHeads, causal)
+}
+
+// newMultiHeadAttention creates a self-attention layer whose parameter names start with prefix.
+// Sublayer parameters are named "<prefix><sublayer>.<name>" when they are created, so that
+// they stay unique once registered by the enclosing layer.
+func newMultiHeadAttention(prefix string, embedDim, numHeads int, causal bool) *MultiHeadAttention {
+	if numHeads <= 0 || embedDim%numHeads != 0 {
+		panic(fmt.Sprintf("MultiHeadAttention: embedding dimension %d cannot be split into %d heads", embedDim, numHeads))
+	}
+	m := &MultiHeadAttention{
+		EmbedDim: embedDim,
+		NumHeads: numHeads,
+		Causal:   causal,
+		Query:    newLinear(prefix+"query.", embedDim, embedDim),
+		Key:      newLinear(prefix+"key.", embedDim, embedDim),
+		Value:    newLinear(prefix+"value.", embedDim, embedDim),
+		Output:   newLinear(prefix+"output.", embedDim, embedDim),
+	}
+	var params []*autograd.Parameter
+	for _, sub := range []Layer{m.Query, m.Key, m.Value, m.Output} {
+		params = append(params, sub.Parameters()...)
+	}
+	m.Module = NewModule(m.forward, params...)
+	return m
+}
+
+// SetKeyPaddingMask marks padded positions that no query may attend to.
+// mask has shape (batch, seq) with non-zero entries at padded positions; nil clears it.
+func (m *MultiHeadAttention) SetKeyPaddingMask(mask *tensor.Dense) {
+	if mask == nil {
+		m.keyMask, m.maskShape = nil, nil
+		return
+	}
+	if len(mask.Shape()) != 2 {
+		panic(fmt.Sprintf("MultiHeadAttention: expected padding mask (batch, seq), got shape %v", mask.Shape()))
+	}
+	data := mask.Data().([]float64)
+	m.keyMask = make([]bool, len(data))
+	for i, v := range data {
+		m.keyMask[i] = v != 0
+	}
+	m.maskShape = append([]int(nil), mask.Shape()...)
+}
+
+// forward computes softmax(Q K^T / sqrt(headDim) + mask) V for every head
+func (m *MultiHeadAttention) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 3 || shape[2] != m.EmbedDim {
+		panic(fmt.Sprintf("MultiHeadAttention: expected input (batch, seq, %d), got shape %v", m.EmbedDim, shape))
+	}
+	batch, seqLen := shape[0], shape[1]
+	headDim := m.EmbedDim / m.NumHeads
+
+	// (batch*seq, embed) -> (batch*heads, seq, headDim)
+	rows := t.Reshape(x, batch*seqLen, m.EmbedDim)
+	splitHeads := func(proj *Linear) *autograd.Variable {
+		heads := t.Reshape(proj.forward(t, rows), batch, seqLen, m.NumHeads, headDim)
+		return t.Reshape(t.Permute(heads, 0, 2, 1, 3), batch*m.NumHeads, seqLen, headDim)
+	}
+	q, k, v := splitHeads(m.Query), splitHeads(m.Key), splitHeads(m.Value)
+
+	scores := t.Scale(t.BatchMatMul(q, t.Permute(k, 0, 2, 1)), 1/math.Sqrt(float64(headDim)))
+	if mask := m.attentionMask(batch, seqLen); mask != nil {
+		scores = t.Add(scores, mask)
+	}
+	context := t.BatchMatMul(t.Softmax(scores), v)
+
+	// (batch*heads, seq, headDim) -> (batch*seq, embed)
+	merged := t.Permute(t.Reshape(context, batch, m.NumHeads, seqLen, headDim), 0, 2, 1, 3)
+	out := m.Output.forward(t, t.Reshape(merged, batch*seqLen, m.EmbedDim))
+	return t.Reshape(out, batch, seqLen, m.EmbedDim)
+}
+
+// attentionMask builds the additive (batch*heads, seq, seq) mask, or nil if nothing is masked
+func (m *MultiHeadAttention) attentionMask(batch, seqLen int) *autograd.Variable {
+	if !m.Causal && m.keyMask == nil {
+		return nil
+	}
+	if m.keyMask != nil && (m.maskShape[0] != batch || m.maskShape[1] != seqLen) {
+		panic(fmt.Sprintf("MultiHeadAttention: padding mask shape %v does not match input (%d, %d)", m.maskShape, batch, seqLen))
+	}
+
+	data := make([]float64, batch*m.NumHeads*seqLen*seqLen)
+	for b := 0; b < batch; b++ {
+		for h := 0; h < m.NumHeads; h++ {
+			block := data[(b*m.NumHeads+h)*seqLen*seqLen:]
+			for i := 0; i < seqLen; i++ {
+				for j := 0; j < seqLen; j++ {
+					if (m.Causal && j > i) || (m.keyMask != nil && m.keyMask[b*seqLen+j]) {
+						block[i*seqLen+j] = maskedScore
+					}
+				}
+			}
+		}
+	}
+	return constant(data, batch*m.NumHeads, seqLen, seqLen)
+}
+
+// TransformerEncoderLayer is a post-norm Transformer encoder block over (batch, seq, embedDim):
+// x = LayerNorm(x + Attention(x)); x = LayerNorm(x + FeedForward(x))
+type TransformerEncoderLayer struct {
+	*Module
+	EmbedDim       int                 // size of each token embedding
+	NumHeads       int                 // number of attention heads
+	FeedForwardDim int                 // hidden size of the feed-forward network
+	Attention      *MultiHeadAttention // self-attention sublayer
+	Linear1        *Linear             // feed-forward expansion (embedDim, feedForwardDim)
+	Linear2        *Linear             // feed-forward projection (feedForwardDim, embedDim)
+	Norm1          *LayerNorm          // normalization after the attention residual
+	Norm2          *LayerNorm          // normalization after the feed-forward residual
+}
+
+// NewTransformerEncoderLayer creates an encoder block with a ReLU feed-forward network
+func NewTransformerEncoderLayer(embedDim, numHeads, feedForwardDim int, causal bool) *TransformerEncoderLayer {
+	e := &TransformerEncoderLayer{
+		EmbedDim:       embedDim,
+		NumHeads:       numHeads,
+		FeedForwardDim: feedForwardDim,
+		Attention:      newMultiHeadAttention("attention.", embedDim, numHeads, causal),
+		Linear1:        newLinear("linear1.", embedDim, feedForwardDim),
+		Linear2:        newLinear("linear2.", feedForwardDim, embedDim),
+		Norm1:          newLayerNorm("norm1.", []int{embedDim}, 1e-5, true),
+		Norm2:          newLayerNorm("norm2.", []int{embedDim}, 1e-5, true),
+	}
+	var params []*autograd.Parameter
+	for _, sub := range []Layer{e.Attention, e.Linear1, e.Linear2, e.Norm1, e.Norm2} {
+		params = append(params, sub.Parameters()...)
+	}
+	e.Module = NewModule(e.forward, params...)
+	return e
+}
+
+// SetKeyPaddingMask marks padded positions that the attention sublayer must ignore
+func (e *TransformerEncoderLayer) SetKeyPaddingMask(mask *tensor.Dense) {
+	e.Attention.SetKeyPaddingMask(mask)
+}
+
+// forward applies the attention and feed-forward sublayers with residual connections
+func (e *TransformerEncoderLayer) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 3 || shape[2] != e.EmbedDim {
+		panic(fmt.Sprintf("TransformerEncoderLayer: expected input (batch, seq, %d), got shape %v", e.EmbedDim, shape))
+	}
+	x = e.Norm1.forward(t, t.Add(x, e.Attention.forward(t, x)))
+
+	rows := t.Reshape(x, shape[0]*shape[1], e.EmbedDim)
+	hidden := e.Linear2.forward(t, t.ReLU(e.Linear1.forward(t, rows)))
+	return e.Norm2.forward(t, t.Add(x, t.Reshape(hidden, shape...)))
+}
@@ -0,0 +1,164 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// batchNorm holds the state shared by BatchNorm1d and BatchNorm2d.
+// Statistics are computed per channel (axis 1) over every other axis.
+type batchNorm struct {
+	*Module
+	NumFeatures int                 // number of channels being normalized
+	Eps         float64             // added to the variance for numerical stability
+	Momentum    float64             // weight of the current batch in the running statistics
+	Gamma       *autograd.Parameter // learnable scale (numFeatures)
+	Beta        *autograd.Parameter // learnable shift (numFeatures)
+	RunningMean *autograd.Parameter // running mean used in eval mode (not trained)
+	RunningVar  *autograd.Parameter // running variance used in eval mode (not trained)
+	training    bool                // training mode flag
+}
+
+func newBatchNorm(numFeatures int) batchNorm {
+	runningVarData := make([]float64, numFeatures)
+	for i := range runningVarData {
+		runningVarData[i] = 1.0
+	}
+
+	b := batchNorm{
+		NumFeatures: numFeatures,
+		Eps:         1e-5,
+		Momentum:    0.1,
+		RunningMean: autograd.NewParameter("running_mean", tensor.New(tensor.WithShape(numFeatures), tensor.WithBacking(make([]float64, numFeatures)))),
+		RunningVar:  autograd.NewParameter("running_var", tensor.New(tensor.WithShape(numFeatures), tensor.WithBacking(runningVarData))),
+		training:    true,
+	}
+	b.Gamma, b.Beta = affineParameters("", numFeatures)
+	b.RunningMean.RequiresGrad = false
+	b.RunningVar.RequiresGrad = false
+	return b
+}
+
+// SetTraining switches between batch statistics (training) and running statistics (eval)
+func (b *batchNorm) SetTraining(training bool) {
+	b.training = training
+}
+
+// forward normalizes x per channel and applies the affine transform
+func (b *batchNorm) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	rows, restore := toChannelsLast(t, x)
+
+	var normalized *autograd.Variable
+	if b.training {
+		var mean, variance *autograd.Variable
+		normalized, mean, variance = standardizeColumns(t, rows, b.Eps)
+		b.updateRunningStats(mean.Data(), variance.Data(), rows.Shape()[0])
+	} else {
+		negMean := make([]float64, b.NumFeatures)
+		invStd := make([]float64, b.NumFeatures)
+		runningMean, runningVar := b.RunningMean.Data(), b.RunningVar.Data()
+		for i := range negMean {
+			negMean[i] = -runningMean[i]
+			invStd[i] = 1.0 / math.Sqrt(runningVar[i]+b.Eps)
+		}
+		normalized = t.MulRow(t.AddRow(rows, constant(negMean)), constant(invStd))
+	}
+
+	return restore(t.AddRow(t.MulRow(normalized, b.Gamma.Var()), b.Beta.Var()))
+}
+
+// updateRunningStats blends the batch statistics into the running estimates,
+// using the unbiased variance like PyTorch
+func (b *batchNorm) updateRunningStats(mean, variance []float64, count int) {
+	correction := 1.0
+	if count > 1 {
+		correction = float64(count) / float64(count-1)
+	}
+	runningMean, runningVar := b.RunningMean.Data(), b.RunningVar.Data()
+	for i := range runningMean {
+		runningMean[i] = (1-b.Momentum)*runningMean[i] + b.Momentum*mean[i]
+		runningVar[i] = (1-b.Momentum)*runningVar[i] + b.Momentum*variance[i]*correction
+	}
+}
+
+// BatchNorm1d normalizes (N, C) or (N, C, L) input per feature over the batch
+type BatchNorm1d struct {
+	batchNorm
+}
+
+// NewBatchNorm1d creates a batch normalization layer for numFeatures features (eps=1e-5, momentum=0.1)
+func NewBatchNorm1d(numFeatures int) *BatchNorm1d {
+	b := &BatchNorm1d{newBatchNorm(numFeatures)}
+	b.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		if shape := x.Shape(); (len(shape) != 2 && len(shape) != 3) || shape[1] != b.NumFeatures {
+			panic(fmt.Sprintf("BatchNorm1d: expected input (N, %d) or (N, %d, L), got shape %v", b.NumFeatures, b.NumFeatures, shape))
+		}
+		return b.forward(t, x)
+	}, b.Gamma, b.Beta, b.RunningMean, b.RunningVar)
+	return b
+}
+
+// BatchNorm2d normalizes NCHW input per channel over the batch and spatial dimensions
+type BatchNorm2d struct {
+	batchNorm
+}
+
+// NewBatchNorm2d creates a batch normalization layer for numFeatures channels (eps=1e-5, momentum=0.1)
+func NewBatchNorm2d(numFeatures int) *BatchNorm2d {
+	b := &BatchNorm2d{newBatchNorm(numFeatures)}
+	b.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		if shape := x.Shape(); len(shape) != 4 || shape[1] != b.NumFeatures {
+			panic(fmt.Sprintf("BatchNorm2d: expected input (N, %d, H, W), got shape %v", b.NumFeatures, shape))
+		}
+		return b.forward(t, x)
+	}, b.Gamma, b.Beta, b.RunningMean, b.RunningVar)
+	return b
+}
+
+// toChannelsLast moves axis 1 of x to the end and flattens the rest into rows,
+// returning the (rows, channels) view and a function that undoes the transform
+func toChannelsLast(t *autograd.Tape, x *autograd.Variable) (*autograd.Variable, func(*autograd.Variable) *autograd.Variable) {
+	shape := x.Shape()
+	channels := shape[1]
+	if len(shape) == 2 {
+		return x, func(y *autograd.Variable) *autograd.Variable { return y }
+	}
+
+	axes := []int{0}
+	for i := 2; i < len(shape); i++ {
+		axes = append(axes, i)
+	}
+	axes = append(axes, 1)
+	permuted := t.Permute(x, axes...)
+	permutedShape := append([]int(nil), permuted.Shape()...)
+	rows := t.Reshape(permuted, len(x.Data())/channels, channels)
+
+	inverse := make([]int, len(axes))
+	for i, axis := range axes {
+		inverse[axis] = i
+	}
+	return rows, func(y *autograd.Variable) *autograd.Variable {
+		return t.Permute(t.Reshape(y, permutedShape...), inverse...)
+	}
+}
+
+// standardizeColumns shifts and scales every column of a 2D variable to zero mean and
+// unit (biased) variance, also returning the column means and variances
+func standardizeColumns(t *autograd.Tape, x *autograd.Variable, eps float64) (normalized, mean, variance *autograd.Variable) {
+	mean = t.MeanRows(x)
+	centered := t.AddRow(x, t.Neg(mean))
+	variance = t.MeanRows(t.Square(centered))
+	normalized = t.MulRow(centered, t.Pow(t.AddScalar(variance, eps), -0.5))
+	return normalized, mean, variance
+}
+
+// constant wraps data in a variable that does not require gradients
+func constant(data []float64, shape ...int) *autograd.Variable {
+	if len(shape) == 0 {
+		shape = []int{len(data)}
+	}
+	return autograd.NewVariable(tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data)), false)
+}
@@ -0,0 +1,82 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// Conv1D applies a 1D convolution over (N, C, L) input using im2col.
+// With groups > 1 the channels are split into independent groups, each convolved with
+// its own filters; groups == inChannels gives a depthwise convolution.
+type Conv1D struct {
+	*Module
+	InChannels  int                 // number of input channels
+	OutChannels int                 // number of output channels (filters)
+	KernelSize  int                 // length of the kernel
+	Stride      int                 // step between neighbouring windows
+	Padding     int                 // zero padding added to both ends of the sequence
+	Dilation    int                 // spacing between kernel elements
+	Groups      int                 // number of channel groups, dividing both channel counts
+	Weight      *autograd.Parameter // learnable kernels (outChannels, inChannels/groups, kernel)
+	Bias        *autograd.Parameter // learnable bias (outChannels)
+}
+
+// NewConv1D creates a new 1D convolution layer with Xavier initialization
+func NewConv1D(inChannels, outChannels, kernel, stride, padding, dilation, groups int) *Conv1D {
+	if groups <= 0 || inChannels%groups != 0 || outChannels%groups != 0 {
+		panic(fmt.Sprintf("Conv1D: %d input and %d output channels cannot be split into %d groups", inChannels, outChannels, groups))
+	}
+	fanIn := inChannels / groups * kernel
+	fanOut := outChannels / groups * kernel
+	weightData := make([]float64, outChannels*fanIn)
+	limit := math.Sqrt(6.0 / float64(fanIn+fanOut))
+	for i := range weightData {
+		weightData[i] = (rng.Float64()*2 - 1) * limit
+	}
+	weightMat := tensor.New(tensor.WithShape(outChannels, inChannels/groups, kernel), tensor.WithBacking(weightData))
+	biasMat := tensor.New(tensor.WithShape(outChannels), tensor.WithBacking(make([]float64, outChannels)))
+
+	c := &Conv1D{
+		InChannels:  inChannels,
+		OutChannels: outChannels,
+		KernelSize:  kernel,
+		Stride:      stride,
+		Padding:     padding,
+		Dilation:    dilation,
+		Groups:      groups,
+		Weight:      autograd.NewParameter("weight", weightMat),
+		Bias:        autograd.NewParameter("bias", biasMat),
+	}
+	c.Module = NewModule(c.forward, c.Weight, c.Bias)
+	return c
+}
+
+// forward convolves x (N, C, L) into (N, outChannels, outL)
+func (c *Conv1D) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 3 || shape[1] != c.InChannels {
+		panic(fmt.Sprintf("Conv1D: expected input (N, %d, L), got shape %v", c.InChannels, shape))
+	}
+	n := shape[0]
+	outL := autograd.ConvOutputSize(shape[2], c.KernelSize, c.Stride, c.Padding, c.Dilation)
+
+	// Columns are laid out channel-major, so each group owns a contiguous block of columns
+	cols := t.Im2Col1D(x, c.KernelSize, c.Stride, c.Padding, c.Dilation)
+	groupCols := c.InChannels / c.Groups * c.KernelSize
+	groupOut := c.OutChannels / c.Groups
+	kernel := t.Reshape(c.Weight.Var(), c.OutChannels, groupCols)
+	outputs := make([]*autograd.Variable, c.Groups)
+	for g := range outputs {
+		groupKernel := t.Narrow(kernel, 0, g*groupOut, groupOut)
+		outputs[g] = t.MatMul(t.Narrow(cols, 1, g*groupCols, groupCols), t.Transpose(groupKernel))
+	}
+	out := outputs[0]
+	if c.Groups > 1 {
+		out = t.Concat(1, outputs...)
+	}
+	out = t.AddRow(out, c.Bias.Var())
+	return t.Permute(t.Reshape(out, n, outL, c.OutChannels), 0, 2, 1)
+}
@@ -0,0 +1,65 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// Conv2D applies a 2D convolution over NCHW input using im2col and a single matrix product
+type Conv2D struct {
+	*Module
+	InChannels  int                 // number of input channels
+	OutChannels int                 // number of output channels (filters)
+	KernelSize  int                 // height and width of the square kernel
+	Stride      int                 // step between neighbouring windows
+	Padding     int                 // zero padding added to every spatial border
+	Dilation    int                 // spacing between kernel elements
+	Weight      *autograd.Parameter // learnable kernels (outChannels, inChannels, kernel, kernel)
+	Bias        *autograd.Parameter // learnable bias (outChannels)
+}
+
+// NewConv2D creates a new 2D convolution layer with Xavier initialization
+func NewConv2D(inChannels, outChannels, kernel, stride, padding, dilation int) *Conv2D {
+	fanIn := inChannels * kernel * kernel
+	fanOut := outChannels * kernel * kernel
+	weightData := make([]float64, outChannels*fanIn)
+	limit := math.Sqrt(6.0 / float64(fanIn+fanOut))
+	for i := range weightData {
+		weightData[i] = (rng.Float64()*2 - 1) * limit
+	}
+	weightMat := tensor.New(tensor.WithShape(outChannels, inChannels, kernel, kernel), tensor.WithBacking(weightData))
+	biasMat := tensor.New(tensor.WithShape(outChannels), tensor.WithBacking(make([]float64, outChannels)))
+
+	c := &Conv2D{
+		InChannels:  inChannels,
+		OutChannels: outChannels,
+		KernelSize:  kernel,
+		Stride:      stride,
+		Padding:     padding,
+		Dilation:    dilation,
+		Weight:      autograd.NewParameter("weight", weightMat),
+		Bias:        autograd.NewParameter("bias", biasMat),
+	}
+	c.Module = NewModule(c.forward, c.Weight, c.Bias)
+	return c
+}
+
+// forward convolves x (N, C, H, W) into (N, outChannels, outH, outW)
+func (c *Conv2D) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 4 || shape[1] != c.InChannels {
+		panic(fmt.Sprintf("Conv2D: expected input (N, %d, H, W), got shape %v", c.InChannels, shape))
+	}
+	n := shape[0]
+	outH := autograd.ConvOutputSize(shape[2], c.KernelSize, c.Stride, c.Padding, c.Dilation)
+	outW := autograd.ConvOutputSize(shape[3], c.KernelSize, c.Stride, c.Padding, c.Dilation)
+
+	cols := t.Im2Col(x, c.KernelSize, c.KernelSize, c.Stride, c.Padding, c.Dilation)
+	kernel := t.Reshape(c.Weight.Var(), c.OutChannels, c.InChannels*c.KernelSize*c.KernelSize)
+	out := t.AddRow(t.MatMul(cols, t.Transpose(kernel)), c.Bias.Var())
+	out = t.Reshape(out, n, outH, outW, c.OutChannels)
+	return t.Permute(out, 0, 3, 1, 2)
+}
@@ -0,0 +1,78 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// ConvTranspose2D applies a transposed (fractionally strided) 2D convolution over NCHW input.
+// Every input pixel scatters a weighted copy of the kernel into the output via col2im,
+// which makes it the gradient of Conv2D with the same settings and a learnable upsampler.
+type ConvTranspose2D struct {
+	*Module
+	InChannels    int                 // number of input channels
+	OutChannels   int                 // number of output channels
+	KernelSize    int                 // height and width of the square kernel
+	Stride        int                 // spacing of the input pixels in the output
+	Padding       int                 // rows and columns removed from every output border
+	OutputPadding int                 // extra rows and columns added to the bottom and right, less than Stride
+	Dilation      int                 // spacing between kernel elements
+	Weight        *autograd.Parameter // learnable kernels (inChannels, outChannels, kernel, kernel)
+	Bias          *autograd.Parameter // learnable bias (outChannels)
+}
+
+// NewConvTranspose2D creates a new transposed convolution layer with Xavier initialization
+func NewConvTranspose2D(inChannels, outChannels, kernel, stride, padding, outputPadding, dilation int) *ConvTranspose2D {
+	if outputPadding < 0 || outputPadding >= stride {
+		panic(fmt.Sprintf("ConvTranspose2D: output padding %d must be in [0, stride %d)", outputPadding, stride))
+	}
+	fanIn := inChannels * kernel * kernel
+	fanOut := outChannels * kernel * kernel
+	weightData := make([]float64, inChannels*fanOut)
+	limit := math.Sqrt(6.0 / float64(fanIn+fanOut))
+	for i := range weightData {
+		weightData[i] = (rng.Float64()*2 - 1) * limit
+	}
+	weightMat := tensor.New(tensor.WithShape(inChannels, outChannels, kernel, kernel), tensor.WithBacking(weightData))
+	biasMat := tensor.New(tensor.WithShape(outChannels), tensor.WithBacking(make([]float64, outChannels)))
+
+	c := &ConvTranspose2D{
+		InChannels:    inChannels,
+		OutChannels:   outChannels,
+		KernelSize:    kernel,
+		Stride:        stride,
+		Padding:       padding,
+		OutputPadding: outputPadding,
+		Dilation:      dilation,
+		Weight:        autograd.NewParameter("weight", weightMat),
+		Bias:          autograd.NewParameter("bias", biasMat),
+	}
+	c.Module = NewModule(c.forward, c.Weight, c.Bias)
+	return c
+}
+
+// forward upsamples x (N, C, H, W) into (N, outChannels, outH, outW)
+func (c *ConvTranspose2D) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 4 || shape[1] != c.InChannels {
+		panic(fmt.Sprintf("ConvTranspose2D: expected input (N, %d, H, W), got shape %v", c.InChannels, shape))
+	}
+	n, h, w := shape[0], shape[2], shape[3]
+	outH := autograd.ConvTransposeOutputSize(h, c.KernelSize, c.Stride, c.Padding, c.OutputPadding, c.Dilation)
+	outW := autograd.ConvTransposeOutputSize(w, c.KernelSize, c.Stride, c.Padding, c.OutputPadding, c.Dilation)
+	if outH <= 0 || outW <= 0 {
+		panic(fmt.Sprintf("ConvTranspose2D: padding %d leaves no output for input %dx%d", c.Padding, h, w))
+	}
+
+	// (N*H*W, inChannels) x (inChannels, outChannels*k*k) gives one kernel patch per input pixel
+	pixels := t.Reshape(t.Permute(x, 0, 2, 3, 1), n*h*w, c.InChannels)
+	kernel := t.Reshape(c.Weight.Var(), c.InChannels, c.OutChannels*c.KernelSize*c.KernelSize)
+	cols := t.MatMul(pixels, kernel)
+	out := t.Col2Im(cols, n, c.OutChannels, outH, outW, c.KernelSize, c.KernelSize, c.Stride, c.Padding, c.Dilation)
+
+	rows, restore := toChannelsLast(t, out)
+	return restore(t.AddRow(rows, c.Bias.Var()))
+}
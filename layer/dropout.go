@@ -1,6 +1,7 @@
 package layer
 
 import (
+	"github.com/VigyatGoel/gotorch/autograd"
 	"gorgonia.org/tensor"
 	"math/rand"
 )
@@ -57,12 +58,8 @@ func (d *Dropout) Backward(gradOutput *tensor.Dense) *tensor.Dense {
 	return result.(*tensor.Dense)
 }
 
-func (d *Dropout) GetWeights() *tensor.Dense                 { return nil }
-func (d *Dropout) GetGradients() *tensor.Dense               { return nil }
-func (d *Dropout) UpdateWeights(weightsUpdate *tensor.Dense) {}
-func (d *Dropout) GetBiases() *tensor.Dense                  { return nil }
-func (d *Dropout) GetBiasGradients() *tensor.Dense           { return nil }
-func (d *Dropout) UpdateBiases(biasUpdate *tensor.Dense)     {}
+// Parameters returns nil since dropout has no learnable parameters
+func (d *Dropout) Parameters() []*autograd.Parameter { return nil }
 
 // ClearCache releases dropout mask to prevent memory leaks
 func (d *Dropout) ClearCache() {
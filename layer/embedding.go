@@ -0,0 +1,99 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// Embedding maps integer indices to dense learnable vectors.
+// Input of shape (N) or (N, L) holds indices stored as float64 values;
+// the output appends an embedding dimension, e.g. (N, L, dim).
+// Backward scatters gradients into the looked-up rows of a table-sized gradient, leaving every
+// other row zero; optimizers with momentum or moment estimates may still move those rows.
+type Embedding struct {
+	*Module
+	NumEmbeddings int                 // size of the lookup table
+	EmbeddingDim  int                 // length of each embedding vector
+	PaddingIdx    int                 // index whose vector receives no gradient (-1 for none)
+	Weight        *autograd.Parameter // lookup table (numEmbeddings, embeddingDim)
+}
+
+// EmbeddingOption configures an optional setting of Embedding
+type EmbeddingOption func(*Embedding)
+
+// WithPaddingIdx marks idx as the padding index, whose vector receives no gradient.
+// NewEmbedding also initializes that vector to zeros; a pre-trained vector is kept as loaded.
+func WithPaddingIdx(idx int) EmbeddingOption {
+	return func(e *Embedding) {
+		e.SetPaddingIdx(idx)
+	}
+}
+
+// NewEmbedding creates an embedding table initialized from a standard normal distribution
+func NewEmbedding(numEmbeddings, dim int, opts ...EmbeddingOption) *Embedding {
+	weightData := make([]float64, numEmbeddings*dim)
+	for i := range weightData {
+		weightData[i] = rng.NormFloat64()
+	}
+	e := newEmbedding(tensor.New(tensor.WithShape(numEmbeddings, dim), tensor.WithBacking(weightData)), opts)
+	if e.PaddingIdx >= 0 {
+		row := weightData[e.PaddingIdx*dim : (e.PaddingIdx+1)*dim]
+		for i := range row {
+			row[i] = 0
+		}
+	}
+	return e
+}
+
+// NewEmbeddingFromPretrained creates an embedding table from a (numEmbeddings, dim) matrix.
+// If freeze is true the table is not updated during training.
+func NewEmbeddingFromPretrained(weights *tensor.Dense, freeze bool, opts ...EmbeddingOption) *Embedding {
+	if len(weights.Shape()) != 2 {
+		panic(fmt.Sprintf("Embedding: pretrained weights must be 2D, got shape %v", weights.Shape()))
+	}
+	e := newEmbedding(weights.Clone().(*tensor.Dense), opts)
+	e.Weight.RequiresGrad = !freeze
+	return e
+}
+
+func newEmbedding(weights *tensor.Dense, opts []EmbeddingOption) *Embedding {
+	shape := weights.Shape()
+	e := &Embedding{
+		NumEmbeddings: shape[0],
+		EmbeddingDim:  shape[1],
+		PaddingIdx:    -1,
+		Weight:        autograd.NewParameter("weight", weights),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.Module = NewModule(e.forward, e.Weight)
+	return e
+}
+
+// SetPaddingIdx marks idx as the padding index, whose vector receives no gradient from then on;
+// the vector itself is left unchanged. A negative idx disables padding.
+func (e *Embedding) SetPaddingIdx(idx int) {
+	if idx >= e.NumEmbeddings {
+		panic(fmt.Sprintf("Embedding: padding index %d out of range [0, %d)", idx, e.NumEmbeddings))
+	}
+	e.PaddingIdx = idx
+}
+
+// forward looks up the vector of every index in x
+func (e *Embedding) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	values := x.Data()
+	indices := make([]int, len(values))
+	for i, v := range values {
+		if v != math.Trunc(v) {
+			panic(fmt.Sprintf("Embedding: expected integer indices, got %v", v))
+		}
+		indices[i] = int(v)
+	}
+
+	outShape := append(append([]int(nil), x.Shape()...), e.EmbeddingDim)
+	return t.Reshape(t.Embedding(e.Weight.Var(), indices, e.PaddingIdx), outShape...)
+}
@@ -1,6 +1,7 @@
 package layer
 
 import (
+	"github.com/VigyatGoel/gotorch/autograd"
 	"gorgonia.org/tensor"
 )
 
@@ -39,12 +40,8 @@ func (f *Flatten) Backward(gradOutput *tensor.Dense) *tensor.Dense {
 	)
 }
 
-func (f *Flatten) GetWeights() *tensor.Dense                 { return nil }
-func (f *Flatten) GetGradients() *tensor.Dense               { return nil }
-func (f *Flatten) UpdateWeights(weightsUpdate *tensor.Dense) {}
-func (f *Flatten) GetBiases() *tensor.Dense                  { return nil }
-func (f *Flatten) GetBiasGradients() *tensor.Dense           { return nil }
-func (f *Flatten) UpdateBiases(biasUpdate *tensor.Dense)     {}
+// Parameters returns nil since flatten has no learnable parameters
+func (f *Flatten) Parameters() []*autograd.Parameter { return nil }
 
 // ClearCache releases cached shape to prevent memory leaks
 func (f *Flatten) ClearCache() {
@@ -0,0 +1,309 @@
+package layer_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/layer"
+	"gorgonia.org/tensor"
+)
+
+// randomDense returns a tensor of the given shape with standard normal entries
+func randomDense(rng *rand.Rand, shape ...int) *tensor.Dense {
+	data := make([]float64, tensor.Shape(shape).TotalSize())
+	for i := range data {
+		data[i] = rng.NormFloat64()
+	}
+	return tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data))
+}
+
+// checkLayer compares the input and parameter gradients Backward gives with central finite
+// differences of sum(seed * Forward(x)) for a fixed random seed. Input gradients are skipped
+// for inputs holding indices.
+func checkLayer(t *testing.T, rng *rand.Rand, l layer.Layer, x *tensor.Dense, indices bool) {
+	t.Helper()
+	const h, tolerance = 1e-6, 1e-6
+
+	out := l.Forward(x)
+	seed := randomDense(rng, out.Shape()...)
+	for _, p := range l.Parameters() {
+		p.ZeroGrad()
+	}
+	gradX := append([]float64(nil), l.Backward(seed).Data().([]float64)...)
+
+	seedData := seed.Data().([]float64)
+	objective := func() float64 {
+		sum := 0.0
+		for i, v := range l.Forward(x).Data().([]float64) {
+			sum += seedData[i] * v
+		}
+		return sum
+	}
+	check := func(what string, data, grad []float64) {
+		t.Helper()
+		for i := range data {
+			orig := data[i]
+			data[i] = orig + h
+			plus := objective()
+			data[i] = orig - h
+			minus := objective()
+			data[i] = orig
+
+			numeric := (plus - minus) / (2 * h)
+			analytic := 0.0
+			if grad != nil {
+				analytic = grad[i]
+			}
+			if math.Abs(numeric-analytic) > tolerance {
+				t.Fatalf("%s gradient[%d] = %v, finite differences give %v", what, i, analytic, numeric)
+			}
+		}
+	}
+
+	// Parameter gradients are copied first because every forward pass below rebuilds the tape
+	grads := make([][]float64, len(l.Parameters()))
+	for i, p := range l.Parameters() {
+		if p.Grad != nil {
+			grads[i] = append([]float64(nil), p.Grad.Data().([]float64)...)
+		}
+	}
+	if !indices {
+		check("input", x.Data().([]float64), gradX)
+	}
+	for i, p := range l.Parameters() {
+		if p.RequiresGrad {
+			check(p.Name, p.Data(), grads[i])
+		}
+	}
+}
+
+// layerCase is one layer and the input it is checked at
+type layerCase struct {
+	name    string
+	layer   layer.Layer
+	input   *tensor.Dense
+	indices bool // the input holds indices, so only parameter gradients are checked
+}
+
+// runLayerCases gradient-checks every case
+func runLayerCases(t *testing.T, rng *rand.Rand, cases []layerCase) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checkLayer(t, rng, c.layer, c.input, c.indices)
+		})
+	}
+}
+
+func TestConvolutionGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	runLayerCases(t, rng, []layerCase{
+		{name: "Conv2D", layer: layer.NewConv2D(2, 3, 3, 1, 0, 1), input: randomDense(rng, 2, 2, 5, 5)},
+		{name: "Conv2D stride 2 padding 1", layer: layer.NewConv2D(2, 3, 3, 2, 1, 1), input: randomDense(rng, 2, 2, 6, 5)},
+		{name: "Conv2D dilation 2", layer: layer.NewConv2D(2, 2, 3, 1, 2, 2), input: randomDense(rng, 1, 2, 6, 6)},
+		{name: "Conv2D 1x1", layer: layer.NewConv2D(3, 2, 1, 1, 0, 1), input: randomDense(rng, 2, 3, 3, 4)},
+		{name: "Conv1D", layer: layer.NewConv1D(2, 3, 3, 1, 0, 1, 1), input: randomDense(rng, 2, 2, 6)},
+		{name: "Conv1D stride 2 padding 1 dilation 2", layer: layer.NewConv1D(2, 2, 3, 2, 1, 2, 1), input: randomDense(rng, 1, 2, 9)},
+		{name: "Conv1D groups", layer: layer.NewConv1D(4, 6, 2, 1, 1, 1, 2), input: randomDense(rng, 2, 4, 5)},
+		{name: "Conv1D depthwise", layer: layer.NewConv1D(3, 3, 3, 1, 1, 1, 3), input: randomDense(rng, 2, 3, 5)},
+		{name: "ConvTranspose2D", layer: layer.NewConvTranspose2D(2, 3, 3, 1, 0, 0, 1), input: randomDense(rng, 2, 2, 3, 3)},
+		{name: "ConvTranspose2D stride 2 padding 1 output padding 1", layer: layer.NewConvTranspose2D(2, 2, 3, 2, 1, 1, 1), input: randomDense(rng, 1, 2, 3, 2)},
+		{name: "ConvTranspose2D dilation 2", layer: layer.NewConvTranspose2D(2, 2, 2, 1, 0, 0, 2), input: randomDense(rng, 1, 2, 3, 3)},
+	})
+}
+
+func TestPoolingGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	runLayerCases(t, rng, []layerCase{
+		{name: "MaxPool2D", layer: layer.NewMaxPool2D(2, 0, 0), input: randomDense(rng, 2, 2, 4, 6)},
+		{name: "MaxPool2D stride 1 padding 1", layer: layer.NewMaxPool2D(3, 1, 1), input: randomDense(rng, 1, 2, 5, 5)},
+		{name: "AvgPool2D", layer: layer.NewAvgPool2D(2, 0, 0), input: randomDense(rng, 2, 2, 4, 6)},
+		{name: "AvgPool2D stride 2 padding 1", layer: layer.NewAvgPool2D(3, 2, 1), input: randomDense(rng, 1, 2, 5, 6)},
+		{name: "AdaptiveAvgPool2D", layer: layer.NewAdaptiveAvgPool2D(2, 3), input: randomDense(rng, 2, 2, 5, 7)},
+		{name: "AdaptiveAvgPool2D global", layer: layer.NewAdaptiveAvgPool2D(1, 1), input: randomDense(rng, 2, 3, 3, 3)},
+		{name: "MaxPool1D", layer: layer.NewMaxPool1D(2, 0, 0), input: randomDense(rng, 2, 2, 6)},
+		{name: "MaxPool1D stride 1 padding 1", layer: layer.NewMaxPool1D(3, 1, 1), input: randomDense(rng, 1, 3, 5)},
+		{name: "AvgPool1D", layer: layer.NewAvgPool1D(2, 0, 0), input: randomDense(rng, 2, 2, 6)},
+		{name: "AvgPool1D stride 2 padding 1", layer: layer.NewAvgPool1D(3, 2, 1), input: randomDense(rng, 1, 3, 6)},
+		{name: "Upsample nearest", layer: layer.NewUpsample(2, layer.UpsampleNearest), input: randomDense(rng, 2, 2, 2, 3)},
+		{name: "Upsample bilinear", layer: layer.NewUpsample(3, layer.UpsampleBilinear), input: randomDense(rng, 1, 2, 3, 2)},
+	})
+}
+
+func TestNormalizationGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	// Eval mode normalizes with running statistics gathered by an earlier training pass
+	eval := layer.NewBatchNorm2d(3)
+	eval.Forward(randomDense(rng, 4, 3, 2, 2))
+	eval.SetTraining(false)
+
+	runLayerCases(t, rng, []layerCase{
+		{name: "BatchNorm1d", layer: layer.NewBatchNorm1d(3), input: randomDense(rng, 5, 3)},
+		{name: "BatchNorm1d sequence", layer: layer.NewBatchNorm1d(2), input: randomDense(rng, 3, 2, 4)},
+		{name: "BatchNorm2d", layer: layer.NewBatchNorm2d(3), input: randomDense(rng, 2, 3, 3, 2)},
+		{name: "BatchNorm2d eval", layer: eval, input: randomDense(rng, 2, 3, 2, 2)},
+		{name: "LayerNorm", layer: layer.NewLayerNorm([]int{4}, 1e-5, true), input: randomDense(rng, 2, 3, 4)},
+		{name: "LayerNorm two dimensions", layer: layer.NewLayerNorm([]int{3, 4}, 1e-5, true), input: randomDense(rng, 2, 3, 4)},
+		{name: "LayerNorm without affine", layer: layer.NewLayerNorm([]int{5}, 1e-5, false), input: randomDense(rng, 3, 5)},
+		{name: "GroupNorm", layer: layer.NewGroupNorm(2, 4), input: randomDense(rng, 2, 4, 2, 3)},
+		{name: "GroupNorm one group", layer: layer.NewGroupNorm(1, 3), input: randomDense(rng, 2, 3, 4)},
+		{name: "GroupNorm per channel", layer: layer.NewGroupNorm(3, 3), input: randomDense(rng, 2, 3, 2, 2)},
+	})
+}
+
+func TestEmbeddingGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	indices := tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float64{0, 3, 3, 4, 1, 0}))
+	runLayerCases(t, rng, []layerCase{
+		{name: "Embedding", layer: layer.NewEmbedding(5, 3), input: indices, indices: true},
+		{name: "Embedding pretrained", layer: layer.NewEmbeddingFromPretrained(randomDense(rng, 6, 2), false), input: indices, indices: true},
+	})
+}
+
+// TestEmbeddingPaddingIdx checks that the padding vector starts at zero in a new table, keeps its
+// value in a pre-trained one, and never receives a gradient, while rows that were not looked up
+// get a zero gradient
+func TestEmbeddingPaddingIdx(t *testing.T) {
+	pretrained := tensor.New(tensor.WithShape(4, 2), tensor.WithBacking([]float64{1, 2, 3, 4, 5, 6, 7, 8}))
+	fresh := layer.NewEmbedding(4, 2, layer.WithPaddingIdx(1))
+	if row := fresh.Weight.Data()[2:4]; row[0] != 0 || row[1] != 0 {
+		t.Errorf("new table: padding vector %v, want zeros", row)
+	}
+	loaded := layer.NewEmbeddingFromPretrained(pretrained, false, layer.WithPaddingIdx(1))
+	set := layer.NewEmbeddingFromPretrained(pretrained, false)
+	set.SetPaddingIdx(1)
+	for name, e := range map[string]*layer.Embedding{"pretrained option": loaded, "SetPaddingIdx": set} {
+		if row := e.Weight.Data()[2:4]; row[0] != 3 || row[1] != 4 {
+			t.Errorf("%s: padding vector %v, want the pre-trained [3 4]", name, row)
+		}
+	}
+
+	for name, e := range map[string]*layer.Embedding{"new": fresh, "pretrained option": loaded, "SetPaddingIdx": set} {
+		e.Weight.ZeroGrad()
+		out := e.Forward(tensor.New(tensor.WithShape(3), tensor.WithBacking([]float64{1, 2, 1})))
+		ones := make([]float64, out.Shape().TotalSize())
+		for i := range ones {
+			ones[i] = 1
+		}
+		e.Backward(tensor.New(tensor.WithShape(out.Shape()...), tensor.WithBacking(ones)))
+
+		want := []float64{0, 0, 0, 0, 1, 1, 0, 0}
+		for i, g := range e.Weight.Grad.Data().([]float64) {
+			if g != want[i] {
+				t.Errorf("%s: weight gradient %v, want %v", name, e.Weight.Grad.Data(), want)
+				break
+			}
+		}
+	}
+}
+
+func TestRecurrentGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	runLayerCases(t, rng, []layerCase{
+		{name: "RNN", layer: layer.NewRNN(3, 4, 1, false, false), input: randomDense(rng, 2, 4, 3)},
+		{name: "RNN bidirectional two layers sequences", layer: layer.NewRNN(3, 2, 2, true, true), input: randomDense(rng, 2, 3, 3)},
+		{name: "LSTM", layer: layer.NewLSTM(3, 4, 1, false, false), input: randomDense(rng, 2, 4, 3)},
+		{name: "LSTM bidirectional two layers sequences", layer: layer.NewLSTM(2, 3, 2, true, true), input: randomDense(rng, 2, 3, 2)},
+		{name: "GRU", layer: layer.NewGRU(3, 4, 1, false, true), input: randomDense(rng, 2, 4, 3)},
+		{name: "GRU bidirectional two layers", layer: layer.NewGRU(2, 3, 2, true, false), input: randomDense(rng, 2, 3, 2)},
+	})
+}
+
+// TestRecurrentInitialState gradient-checks the initial hidden and cell states of a bidirectional
+// two-layer LSTM and checks that they only apply to the forward pass after they are set
+func TestRecurrentInitialState(t *testing.T) {
+	const h, tolerance = 1e-6, 1e-6
+	rng := rand.New(rand.NewSource(6))
+	lstm := layer.NewLSTM(2, 3, 2, true, true)
+	x := randomDense(rng, 2, 3, 2)
+	h0, c0 := randomDense(rng, 4, 2, 3), randomDense(rng, 4, 2, 3)
+
+	fromZeros := lstm.Forward(x).Data().([]float64)
+	lstm.SetInitialState(h0, c0)
+	out := lstm.Forward(x)
+	seed := randomDense(rng, out.Shape()...)
+	lstm.Backward(seed)
+	gradH0 := lstm.InitialStateGrad().Data().([]float64)
+	gradC0 := lstm.InitialCellStateGrad().Data().([]float64)
+
+	objective := func() float64 {
+		lstm.SetInitialState(h0, c0)
+		sum := 0.0
+		for i, v := range lstm.Forward(x).Data().([]float64) {
+			sum += seed.Data().([]float64)[i] * v
+		}
+		return sum
+	}
+	for name, c := range map[string]struct{ data, grad []float64 }{
+		"h0": {h0.Data().([]float64), gradH0},
+		"c0": {c0.Data().([]float64), gradC0},
+	} {
+		for i := range c.data {
+			orig := c.data[i]
+			c.data[i] = orig + h
+			plus := objective()
+			c.data[i] = orig - h
+			minus := objective()
+			c.data[i] = orig
+
+			if numeric := (plus - minus) / (2 * h); math.Abs(numeric-c.grad[i]) > tolerance {
+				t.Fatalf("%s gradient[%d] = %v, finite differences give %v", name, i, c.grad[i], numeric)
+			}
+		}
+	}
+
+	for i, v := range lstm.Forward(x).Data().([]float64) {
+		if v != fromZeros[i] {
+			t.Fatalf("output[%d] = %v after the initial state was used, want %v as from zeros", i, v, fromZeros[i])
+		}
+	}
+	if lstm.InitialStateGrad() != nil || lstm.InitialCellStateGrad() != nil {
+		t.Errorf("initial state gradients are set after a pass from zeros")
+	}
+}
+
+func TestAttentionGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	padded := layer.NewMultiHeadAttention(4, 2, false)
+	padded.SetKeyPaddingMask(tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float64{0, 0, 1, 0, 1, 1})))
+
+	runLayerCases(t, rng, []layerCase{
+		{name: "MultiHeadAttention", layer: layer.NewMultiHeadAttention(4, 2, false), input: randomDense(rng, 2, 3, 4)},
+		{name: "MultiHeadAttention causal", layer: layer.NewMultiHeadAttention(6, 3, true), input: randomDense(rng, 2, 4, 6)},
+		{name: "MultiHeadAttention padding mask", layer: padded, input: randomDense(rng, 2, 3, 4)},
+		{name: "TransformerEncoderLayer", layer: layer.NewTransformerEncoderLayer(4, 2, 6, false), input: randomDense(rng, 2, 3, 4)},
+		{name: "TransformerEncoderLayer causal", layer: layer.NewTransformerEncoderLayer(4, 1, 5, true), input: randomDense(rng, 1, 4, 4)},
+		{name: "PositionalEncoding", layer: layer.NewPositionalEncoding(6, 4), input: randomDense(rng, 2, 3, 4)},
+		{name: "LearnedPositionalEncoding", layer: layer.NewLearnedPositionalEncoding(6, 4), input: randomDense(rng, 2, 3, 4)},
+	})
+}
+
+// TestAttentionParameterNames checks that sublayer parameters are namespaced once, so names are
+// unique and do not change however often the parameters are listed
+func TestAttentionParameterNames(t *testing.T) {
+	e := layer.NewTransformerEncoderLayer(4, 2, 6, false)
+	e.Attention.Parameters()
+	e.Parameters()
+
+	want := []string{
+		"attention.query.weight", "attention.query.bias", "attention.key.weight", "attention.key.bias",
+		"attention.value.weight", "attention.value.bias", "attention.output.weight", "attention.output.bias",
+		"linear1.weight", "linear1.bias", "linear2.weight", "linear2.bias",
+		"norm1.gamma", "norm1.beta", "norm2.gamma", "norm2.beta",
+	}
+	params := e.Parameters()
+	if len(params) != len(want) {
+		t.Fatalf("got %d parameters, want %d", len(params), len(want))
+	}
+	for i, p := range params {
+		if p.Name != want[i] {
+			t.Errorf("parameter %d is named %q, want %q", i, p.Name, want[i])
+		}
+	}
+
+	if name := layer.NewMultiHeadAttention(4, 2, false).Parameters()[0].Name; name != "query.weight" {
+		t.Errorf("standalone attention parameter is named %q, want %q", name, "query.weight")
+	}
+}
@@ -1,6 +1,9 @@
 package layer
 
-import "gorgonia.org/tensor"
+import (
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
 
 // Layer defines the interface that all neural network layers must implement
 type Layer interface {
@@ -8,15 +11,8 @@ type Layer interface {
 	Forward(input *tensor.Dense) *tensor.Dense // computes layer output
 	Backward(dout *tensor.Dense) *tensor.Dense // computes input gradients
 
-	// Weight management (returns nil for layers without weights)
-	GetWeights() *tensor.Dense                 // returns current weights
-	GetGradients() *tensor.Dense               // returns weight gradients
-	UpdateWeights(weightsUpdate *tensor.Dense) // updates weights with new values
-
-	// Bias management (returns nil for layers without biases)
-	GetBiases() *tensor.Dense              // returns current biases
-	GetBiasGradients() *tensor.Dense       // returns bias gradients
-	UpdateBiases(biasUpdate *tensor.Dense) // updates biases with new values
+	// Parameter management (returns nil for layers without parameters)
+	Parameters() []*autograd.Parameter // returns learnable and persistent tensors
 
 	// Memory management
 	ClearCache() // releases cached data to prevent memory leaks
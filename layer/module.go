@@ -0,0 +1,61 @@
+package layer
+
+import (
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// ModuleFunc builds the forward computation of a module on the given tape
+type ModuleFunc func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable
+
+// Module adapts an autograd computation to the Layer interface.
+// Gradients are obtained by replaying the tape recorded during Forward,
+// so Fn can be any composition of autograd operations.
+type Module struct {
+	Fn     ModuleFunc            // forward computation
+	params []*autograd.Parameter // parameters used by Fn
+	tape   *autograd.Tape        // operations recorded by the last forward pass
+	input  *autograd.Variable    // cached input for gradient computation
+	output *autograd.Variable    // cached output to seed the backward pass
+}
+
+// NewModule creates a layer from an autograd function and the parameters it uses
+func NewModule(fn ModuleFunc, params ...*autograd.Parameter) *Module {
+	return &Module{
+		Fn:     fn,
+		params: params,
+	}
+}
+
+// Forward records Fn on a fresh tape and returns its value
+func (m *Module) Forward(x *tensor.Dense) *tensor.Dense {
+	m.tape = autograd.NewTape()
+	m.input = autograd.NewVariable(x, true)
+	m.output = m.Fn(m.tape, m.input)
+	return m.output.Value
+}
+
+// Backward replays the tape to compute input and parameter gradients
+func (m *Module) Backward(gradOutput *tensor.Dense) *tensor.Dense {
+	// Parameter gradients accumulate until the optimizer clears them
+	m.input.Grad = nil
+
+	m.tape.Backward(m.output, gradOutput)
+	if m.input.Grad == nil {
+		shape := m.input.Shape()
+		return tensor.New(tensor.WithShape(shape...), tensor.WithBacking(make([]float64, shape.TotalSize())))
+	}
+	return m.input.Grad
+}
+
+// Parameters returns the parameters used by the module
+func (m *Module) Parameters() []*autograd.Parameter {
+	return m.params
+}
+
+// ClearCache releases the recorded tape and cached tensors to prevent memory leaks
+func (m *Module) ClearCache() {
+	m.tape = nil
+	m.input = nil
+	m.output = nil
+}
@@ -0,0 +1,117 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// LayerNorm normalizes each sample over its trailing normalizedShape dimensions,
+// independently of the batch size
+type LayerNorm struct {
+	*Module
+	NormalizedShape   []int               // trailing dimensions normalized together
+	Eps               float64             // added to the variance for numerical stability
+	ElementwiseAffine bool                // whether Gamma and Beta are learned
+	Gamma             *autograd.Parameter // learnable scale (normalizedShape), nil without affine
+	Beta              *autograd.Parameter // learnable shift (normalizedShape), nil without affine
+}
+
+// NewLayerNorm creates a layer normalization layer over the trailing normalizedShape dimensions
+func NewLayerNorm(normalizedShape []int, eps float64, elementwiseAffine bool) *LayerNorm {
+	return newLayerNorm("", normalizedShape, eps, elementwiseAffine)
+}
+
+// newLayerNorm creates a layer normalization whose parameter names start with prefix
+func newLayerNorm(prefix string, normalizedShape []int, eps float64, elementwiseAffine bool) *LayerNorm {
+	l := &LayerNorm{
+		NormalizedShape:   append([]int(nil), normalizedShape...),
+		Eps:               eps,
+		ElementwiseAffine: elementwiseAffine,
+	}
+
+	var params []*autograd.Parameter
+	if elementwiseAffine {
+		l.Gamma, l.Beta = affineParameters(prefix, normalizedShape...)
+		params = append(params, l.Gamma, l.Beta)
+	}
+	l.Module = NewModule(l.forward, params...)
+	return l
+}
+
+// forward normalizes x over its trailing dimensions
+func (l *LayerNorm) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	lead := len(shape) - len(l.NormalizedShape)
+	if lead < 1 || !tensor.Shape(shape[lead:]).Eq(tensor.Shape(l.NormalizedShape)) {
+		panic(fmt.Sprintf("LayerNorm: expected input (N, ..., %v), got shape %v", l.NormalizedShape, shape))
+	}
+	size := tensor.Shape(l.NormalizedShape).TotalSize()
+
+	// Each sample is a column of the transposed view, so column statistics are per-sample statistics
+	rows := t.Reshape(x, len(x.Data())/size, size)
+	normalized, _, _ := standardizeColumns(t, t.Transpose(rows), l.Eps)
+	out := t.Transpose(normalized)
+	if l.ElementwiseAffine {
+		out = t.AddRow(t.MulRow(out, l.Gamma.Var()), l.Beta.Var())
+	}
+	return t.Reshape(out, shape...)
+}
+
+// GroupNorm divides the channels of (N, C, ...) input into groups and normalizes
+// each group within each sample, followed by a per-channel affine transform
+type GroupNorm struct {
+	*Module
+	NumGroups   int                 // number of channel groups
+	NumChannels int                 // number of channels, divisible by NumGroups
+	Eps         float64             // added to the variance for numerical stability
+	Gamma       *autograd.Parameter // learnable scale (numChannels)
+	Beta        *autograd.Parameter // learnable shift (numChannels)
+}
+
+// NewGroupNorm creates a group normalization layer with eps=1e-5
+func NewGroupNorm(numGroups, numChannels int) *GroupNorm {
+	if numGroups <= 0 || numChannels%numGroups != 0 {
+		panic(fmt.Sprintf("GroupNorm: %d channels cannot be split into %d groups", numChannels, numGroups))
+	}
+	g := &GroupNorm{
+		NumGroups:   numGroups,
+		NumChannels: numChannels,
+		Eps:         1e-5,
+	}
+	g.Gamma, g.Beta = affineParameters("", numChannels)
+	g.Module = NewModule(g.forward, g.Gamma, g.Beta)
+	return g
+}
+
+// forward normalizes each (sample, group) block of x
+func (g *GroupNorm) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) < 2 || shape[1] != g.NumChannels {
+		panic(fmt.Sprintf("GroupNorm: expected input (N, %d, ...), got shape %v", g.NumChannels, shape))
+	}
+	blocks := shape[0] * g.NumGroups
+	blockSize := len(x.Data()) / blocks
+
+	// Each (sample, group) block is contiguous, so it becomes one column of the transposed view
+	rows := t.Reshape(x, blocks, blockSize)
+	normalized, _, _ := standardizeColumns(t, t.Transpose(rows), g.Eps)
+	out := t.Reshape(t.Transpose(normalized), shape...)
+
+	channels, restore := toChannelsLast(t, out)
+	return restore(t.AddRow(t.MulRow(channels, g.Gamma.Var()), g.Beta.Var()))
+}
+
+// affineParameters creates a scale initialized to ones and a shift initialized to zeros,
+// named with the given prefix
+func affineParameters(prefix string, shape ...int) (gamma, beta *autograd.Parameter) {
+	size := tensor.Shape(shape).TotalSize()
+	gammaData := make([]float64, size)
+	for i := range gammaData {
+		gammaData[i] = 1.0
+	}
+	gamma = autograd.NewParameter(prefix+"gamma", tensor.New(tensor.WithShape(shape...), tensor.WithBacking(gammaData)))
+	beta = autograd.NewParameter(prefix+"beta", tensor.New(tensor.WithShape(shape...), tensor.WithBacking(make([]float64, size))))
+	return gamma, beta
+}
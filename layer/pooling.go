@@ -0,0 +1,120 @@
+package layer
+
+import (
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// MaxPool2D downsamples NCHW input by taking the maximum of each window
+type MaxPool2D struct {
+	*Module
+	KernelSize int // height and width of the square window
+	Stride     int // step between neighbouring windows
+	Padding    int // implicit padding on every spatial border (never selected as maximum)
+}
+
+// NewMaxPool2D creates a new max pooling layer; a stride <= 0 defaults to the kernel size
+func NewMaxPool2D(kernel, stride, padding int) *MaxPool2D {
+	if stride <= 0 {
+		stride = kernel
+	}
+	p := &MaxPool2D{
+		KernelSize: kernel,
+		Stride:     stride,
+		Padding:    padding,
+	}
+	p.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		return t.MaxPool2D(x, p.KernelSize, p.Stride, p.Padding)
+	})
+	return p
+}
+
+// AvgPool2D downsamples NCHW input by averaging each window
+type AvgPool2D struct {
+	*Module
+	KernelSize int // height and width of the square window
+	Stride     int // step between neighbouring windows
+	Padding    int // zero padding on every spatial border (included in the average)
+}
+
+// NewAvgPool2D creates a new average pooling layer; a stride <= 0 defaults to the kernel size
+func NewAvgPool2D(kernel, stride, padding int) *AvgPool2D {
+	if stride <= 0 {
+		stride = kernel
+	}
+	p := &AvgPool2D{
+		KernelSize: kernel,
+		Stride:     stride,
+		Padding:    padding,
+	}
+	p.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		return t.AvgPool2D(x, p.KernelSize, p.Stride, p.Padding)
+	})
+	return p
+}
+
+// MaxPool1D downsamples (N, C, L) input by taking the maximum of each window
+type MaxPool1D struct {
+	*Module
+	KernelSize int // length of the window
+	Stride     int // step between neighbouring windows
+	Padding    int // implicit padding at both ends (never selected as maximum)
+}
+
+// NewMaxPool1D creates a new 1D max pooling layer; a stride <= 0 defaults to the kernel size
+func NewMaxPool1D(kernel, stride, padding int) *MaxPool1D {
+	if stride <= 0 {
+		stride = kernel
+	}
+	p := &MaxPool1D{
+		KernelSize: kernel,
+		Stride:     stride,
+		Padding:    padding,
+	}
+	p.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		return t.MaxPool1D(x, p.KernelSize, p.Stride, p.Padding)
+	})
+	return p
+}
+
+// AvgPool1D downsamples (N, C, L) input by averaging each window
+type AvgPool1D struct {
+	*Module
+	KernelSize int // length of the window
+	Stride     int // step between neighbouring windows
+	Padding    int // zero padding at both ends (included in the average)
+}
+
+// NewAvgPool1D creates a new 1D average pooling layer; a stride <= 0 defaults to the kernel size
+func NewAvgPool1D(kernel, stride, padding int) *AvgPool1D {
+	if stride <= 0 {
+		stride = kernel
+	}
+	p := &AvgPool1D{
+		KernelSize: kernel,
+		Stride:     stride,
+		Padding:    padding,
+	}
+	p.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		return t.AvgPool1D(x, p.KernelSize, p.Stride, p.Padding)
+	})
+	return p
+}
+
+// AdaptiveAvgPool2D averages NCHW input down to a fixed spatial size regardless of input resolution
+type AdaptiveAvgPool2D struct {
+	*Module
+	OutputHeight int // output height
+	OutputWidth  int // output width
+}
+
+// NewAdaptiveAvgPool2D creates a new adaptive average pooling layer; use (1, 1) for global pooling
+func NewAdaptiveAvgPool2D(outputHeight, outputWidth int) *AdaptiveAvgPool2D {
+	p := &AdaptiveAvgPool2D{
+		OutputHeight: outputHeight,
+		OutputWidth:  outputWidth,
+	}
+	p.Module = NewModule(func(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+		return t.AdaptiveAvgPool2D(x, p.OutputHeight, p.OutputWidth)
+	})
+	return p
+}
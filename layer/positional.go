@@ -0,0 +1,93 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// PositionalEncoding adds fixed sinusoidal position information to (batch, seq, embedDim) input:
+// PE(pos, 2i) = sin(pos / 10000^(2i/embedDim)), PE(pos, 2i+1) = cos(pos / 10000^(2i/embedDim))
+type PositionalEncoding struct {
+	*Module
+	MaxLen   int       // longest supported sequence
+	EmbedDim int       // size of each token embedding
+	encoding []float64 // precomputed table (maxLen, embedDim)
+}
+
+// NewPositionalEncoding creates a sinusoidal positional encoding for sequences up to maxLen
+func NewPositionalEncoding(maxLen, embedDim int) *PositionalEncoding {
+	p := &PositionalEncoding{
+		MaxLen:   maxLen,
+		EmbedDim: embedDim,
+		encoding: make([]float64, maxLen*embedDim),
+	}
+	for pos := 0; pos < maxLen; pos++ {
+		for i := 0; i < embedDim; i++ {
+			angle := float64(pos) / math.Pow(10000, float64(i-i%2)/float64(embedDim))
+			if i%2 == 0 {
+				p.encoding[pos*embedDim+i] = math.Sin(angle)
+			} else {
+				p.encoding[pos*embedDim+i] = math.Cos(angle)
+			}
+		}
+	}
+	p.Module = NewModule(p.forward)
+	return p
+}
+
+// forward adds the encodings of the first seq positions to every sample
+func (p *PositionalEncoding) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	seqLen := checkSequence("PositionalEncoding", x, p.MaxLen, p.EmbedDim)
+	size := seqLen * p.EmbedDim
+	encoding := constant(append([]float64(nil), p.encoding[:size]...))
+	return addPositions(t, x, encoding)
+}
+
+// LearnedPositionalEncoding adds a trainable vector per position to (batch, seq, embedDim) input
+type LearnedPositionalEncoding struct {
+	*Module
+	MaxLen   int                 // longest supported sequence
+	EmbedDim int                 // size of each token embedding
+	Weight   *autograd.Parameter // learnable position table (maxLen, embedDim)
+}
+
+// NewLearnedPositionalEncoding creates a learned positional encoding initialized from N(0, 0.02^2)
+func NewLearnedPositionalEncoding(maxLen, embedDim int) *LearnedPositionalEncoding {
+	weightData := make([]float64, maxLen*embedDim)
+	for i := range weightData {
+		weightData[i] = rng.NormFloat64() * 0.02
+	}
+	p := &LearnedPositionalEncoding{
+		MaxLen:   maxLen,
+		EmbedDim: embedDim,
+		Weight:   autograd.NewParameter("weight", tensor.New(tensor.WithShape(maxLen, embedDim), tensor.WithBacking(weightData))),
+	}
+	p.Module = NewModule(p.forward, p.Weight)
+	return p
+}
+
+// forward adds the first seq rows of the position table to every sample
+func (p *LearnedPositionalEncoding) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	seqLen := checkSequence("LearnedPositionalEncoding", x, p.MaxLen, p.EmbedDim)
+	positions := t.Reshape(t.Narrow(p.Weight.Var(), 0, 0, seqLen), seqLen*p.EmbedDim)
+	return addPositions(t, x, positions)
+}
+
+// checkSequence validates (batch, seq, embedDim) input and returns seq
+func checkSequence(name string, x *autograd.Variable, maxLen, embedDim int) int {
+	shape := x.Shape()
+	if len(shape) != 3 || shape[2] != embedDim || shape[1] > maxLen {
+		panic(fmt.Sprintf("%s: expected input (batch, seq <= %d, %d), got shape %v", name, maxLen, embedDim, shape))
+	}
+	return shape[1]
+}
+
+// addPositions broadcasts a flattened (seq*embedDim) encoding over the batch of x
+func addPositions(t *autograd.Tape, x, positions *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	rows := t.Reshape(x, shape[0], shape[1]*shape[2])
+	return t.Reshape(t.AddRow(rows, positions), shape...)
+}
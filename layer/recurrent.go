@@ -0,0 +1,303 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// recurrentCell holds the parameters of one layer and direction of a recurrent network.
+// Gate pre-activations are x * weightIH + biasIH + h * weightHH + biasHH.
+type recurrentCell struct {
+	weightIH *autograd.Parameter // (inputSize, gates*hiddenSize)
+	weightHH *autograd.Parameter // (hiddenSize, gates*hiddenSize)
+	biasIH   *autograd.Parameter // (gates*hiddenSize)
+	biasHH   *autograd.Parameter // (gates*hiddenSize)
+}
+
+// project returns the input and hidden contributions to the gate pre-activations
+func (c *recurrentCell) project(t *autograd.Tape, x, h *autograd.Variable) (xGates, hGates *autograd.Variable) {
+	xGates = t.AddRow(t.MatMul(x, c.weightIH.Var()), c.biasIH.Var())
+	hGates = t.AddRow(t.MatMul(h, c.weightHH.Var()), c.biasHH.Var())
+	return xGates, hGates
+}
+
+// stepFunc advances a recurrent cell by one time step; c is the cell state, nil for cells without one
+type stepFunc func(t *autograd.Tape, cell *recurrentCell, hiddenSize int, x, h, c *autograd.Variable) (hNext, cNext *autograd.Variable)
+
+// recurrent implements the sequence loop shared by RNN, LSTM and GRU.
+// Input is (batch, seq, inputSize); the whole unrolled sequence is recorded on the
+// tape, so Backward performs full backpropagation through time.
+type recurrent struct {
+	*Module
+	InputSize       int  // features per time step
+	HiddenSize      int  // size of the hidden state
+	NumLayers       int  // number of stacked layers
+	Bidirectional   bool // whether a second pass runs over the reversed sequence
+	ReturnSequences bool // output (batch, seq, dirs*hidden) instead of the last hidden state (batch, dirs*hidden)
+	cells           []*recurrentCell
+	step            stepFunc
+	hasCell         bool               // whether a cell state is carried besides the hidden state (LSTM only)
+	h0              *autograd.Variable // initial hidden state (layers*dirs, batch, hidden) for the next forward pass only
+	c0              *autograd.Variable // initial cell state for the next forward pass only (LSTM only)
+	lastH0          *autograd.Variable // initial hidden state used by the last forward pass, kept for its gradient
+	lastC0          *autograd.Variable // initial cell state used by the last forward pass, kept for its gradient
+	hn              *tensor.Dense      // final hidden state of the last forward pass
+	cn              *tensor.Dense      // final cell state of the last forward pass (LSTM only)
+}
+
+func newRecurrent(gates, inputSize, hiddenSize, numLayers int, bidirectional, returnSequences, hasCell bool, step stepFunc) *recurrent {
+	r := &recurrent{
+		InputSize:       inputSize,
+		HiddenSize:      hiddenSize,
+		NumLayers:       numLayers,
+		Bidirectional:   bidirectional,
+		ReturnSequences: returnSequences,
+		step:            step,
+		hasCell:         hasCell,
+	}
+
+	// Uniform(-1/sqrt(hidden), 1/sqrt(hidden)) initialization, as in PyTorch
+	limit := 1.0 / math.Sqrt(float64(hiddenSize))
+	uniform := func(name string, shape ...int) *autograd.Parameter {
+		data := make([]float64, tensor.Shape(shape).TotalSize())
+		for i := range data {
+			data[i] = (rng.Float64()*2 - 1) * limit
+		}
+		return autograd.NewParameter(name, tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data)))
+	}
+
+	var params []*autograd.Parameter
+	for l := 0; l < numLayers; l++ {
+		layerInput := inputSize
+		if l > 0 {
+			layerInput = hiddenSize * r.numDirections()
+		}
+		for d := 0; d < r.numDirections(); d++ {
+			suffix := fmt.Sprintf("_l%d", l)
+			if d == 1 {
+				suffix += "_reverse"
+			}
+			cell := &recurrentCell{
+				weightIH: uniform("weight_ih"+suffix, layerInput, gates*hiddenSize),
+				weightHH: uniform("weight_hh"+suffix, hiddenSize, gates*hiddenSize),
+				biasIH:   uniform("bias_ih"+suffix, gates*hiddenSize),
+				biasHH:   uniform("bias_hh"+suffix, gates*hiddenSize),
+			}
+			r.cells = append(r.cells, cell)
+			params = append(params, cell.weightIH, cell.weightHH, cell.biasIH, cell.biasHH)
+		}
+	}
+	r.Module = NewModule(r.forward, params...)
+	return r
+}
+
+func (r *recurrent) numDirections() int {
+	if r.Bidirectional {
+		return 2
+	}
+	return 1
+}
+
+// SetInitialState sets the hidden state (layers*dirs, batch, hidden) the next forward pass
+// starts from; later passes start from zeros again unless it is set again
+func (r *recurrent) SetInitialState(h0 *tensor.Dense) {
+	r.h0 = stateVariable(h0)
+}
+
+// InitialStateGrad returns the gradient of the initial hidden state used by the last forward pass,
+// or nil if that pass started from zeros
+func (r *recurrent) InitialStateGrad() *tensor.Dense {
+	if r.lastH0 == nil {
+		return nil
+	}
+	return r.lastH0.Grad
+}
+
+// HiddenState returns the final hidden state (layers*dirs, batch, hidden) of the last forward pass
+func (r *recurrent) HiddenState() *tensor.Dense {
+	return r.hn
+}
+
+// forward unrolls every layer and direction over the sequence
+func (r *recurrent) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 3 || shape[2] != r.InputSize {
+		panic(fmt.Sprintf("recurrent layer: expected input (batch, seq, %d), got shape %v", r.InputSize, shape))
+	}
+	batch, seqLen := shape[0], shape[1]
+	dirs := r.numDirections()
+
+	// Initial states apply to this pass only, so they cannot leak into the next batch
+	r.lastH0, r.lastC0 = r.h0, r.c0
+	r.h0, r.c0 = nil, nil
+
+	steps := make([]*autograd.Variable, seqLen)
+	for i := range steps {
+		steps[i] = t.Reshape(t.Narrow(x, 1, i, 1), batch, r.InputSize)
+	}
+
+	var finalH, finalC []*autograd.Variable
+	for l := 0; l < r.NumLayers; l++ {
+		outputs := make([][]*autograd.Variable, dirs)
+		for d := 0; d < dirs; d++ {
+			idx := l*dirs + d
+			h := r.initialState(t, r.lastH0, idx, batch)
+			var c *autograd.Variable
+			if r.hasCell {
+				c = r.initialState(t, r.lastC0, idx, batch)
+			}
+			outputs[d] = make([]*autograd.Variable, seqLen)
+			for s := 0; s < seqLen; s++ {
+				i := s
+				if d == 1 {
+					i = seqLen - 1 - s
+				}
+				h, c = r.step(t, r.cells[idx], r.HiddenSize, steps[i], h, c)
+				outputs[d][i] = h
+			}
+			finalH = append(finalH, h)
+			if r.hasCell {
+				finalC = append(finalC, c)
+			}
+		}
+		for i := range steps {
+			if dirs == 1 {
+				steps[i] = outputs[0][i]
+			} else {
+				steps[i] = t.Concat(1, outputs[0][i], outputs[1][i])
+			}
+		}
+	}
+	r.hn = stackStates(finalH, batch, r.HiddenSize)
+	if r.hasCell {
+		r.cn = stackStates(finalC, batch, r.HiddenSize)
+	}
+
+	if r.ReturnSequences {
+		sequence := make([]*autograd.Variable, seqLen)
+		for i, s := range steps {
+			sequence[i] = t.Reshape(s, batch, 1, dirs*r.HiddenSize)
+		}
+		return t.Concat(1, sequence...)
+	}
+	last := finalH[len(finalH)-dirs:]
+	if dirs == 1 {
+		return last[0]
+	}
+	return t.Concat(1, last...)
+}
+
+// initialState returns the (batch, hidden) slice idx of state, or zeros if state is unset
+func (r *recurrent) initialState(t *autograd.Tape, state *autograd.Variable, idx, batch int) *autograd.Variable {
+	if state == nil {
+		return constant(make([]float64, batch*r.HiddenSize), batch, r.HiddenSize)
+	}
+	expected := tensor.Shape{r.NumLayers * r.numDirections(), batch, r.HiddenSize}
+	if !state.Shape().Eq(expected) {
+		panic(fmt.Sprintf("recurrent layer: expected initial state %v, got shape %v", expected, state.Shape()))
+	}
+	return t.Reshape(t.Narrow(state, 0, idx, 1), batch, r.HiddenSize)
+}
+
+// stateVariable wraps an initial state so its gradient can be inspected after backward
+func stateVariable(state *tensor.Dense) *autograd.Variable {
+	if state == nil {
+		return nil
+	}
+	return autograd.NewVariable(state, true)
+}
+
+// stackStates copies per-layer (batch, hidden) states into a (layers*dirs, batch, hidden) tensor
+func stackStates(states []*autograd.Variable, batch, hiddenSize int) *tensor.Dense {
+	data := make([]float64, 0, len(states)*batch*hiddenSize)
+	for _, s := range states {
+		data = append(data, s.Data()...)
+	}
+	return tensor.New(tensor.WithShape(len(states), batch, hiddenSize), tensor.WithBacking(data))
+}
+
+// RNN is an Elman recurrent layer: h' = tanh(x * W_ih + b_ih + h * W_hh + b_hh)
+type RNN struct {
+	*recurrent
+}
+
+// NewRNN creates a (possibly stacked and bidirectional) tanh RNN
+func NewRNN(inputSize, hiddenSize, numLayers int, bidirectional, returnSequences bool) *RNN {
+	return &RNN{newRecurrent(1, inputSize, hiddenSize, numLayers, bidirectional, returnSequences, false, rnnStep)}
+}
+
+func rnnStep(t *autograd.Tape, cell *recurrentCell, hiddenSize int, x, h, c *autograd.Variable) (*autograd.Variable, *autograd.Variable) {
+	xGates, hGates := cell.project(t, x, h)
+	return t.Tanh(t.Add(xGates, hGates)), c
+}
+
+// LSTM is a long short-term memory layer with input, forget, cell and output gates (in that order)
+type LSTM struct {
+	*recurrent
+}
+
+// NewLSTM creates a (possibly stacked and bidirectional) LSTM
+func NewLSTM(inputSize, hiddenSize, numLayers int, bidirectional, returnSequences bool) *LSTM {
+	return &LSTM{newRecurrent(4, inputSize, hiddenSize, numLayers, bidirectional, returnSequences, true, lstmStep)}
+}
+
+// SetInitialState sets the hidden and cell states (layers*dirs, batch, hidden) the next forward
+// pass starts from; later passes start from zeros again unless they are set again
+func (l *LSTM) SetInitialState(h0, c0 *tensor.Dense) {
+	l.h0 = stateVariable(h0)
+	l.c0 = stateVariable(c0)
+}
+
+// InitialCellStateGrad returns the gradient of the initial cell state used by the last forward pass,
+// or nil if that pass started from zeros
+func (l *LSTM) InitialCellStateGrad() *tensor.Dense {
+	if l.lastC0 == nil {
+		return nil
+	}
+	return l.lastC0.Grad
+}
+
+// CellState returns the final cell state (layers*dirs, batch, hidden) of the last forward pass
+func (l *LSTM) CellState() *tensor.Dense {
+	return l.cn
+}
+
+func lstmStep(t *autograd.Tape, cell *recurrentCell, hiddenSize int, x, h, c *autograd.Variable) (*autograd.Variable, *autograd.Variable) {
+	xGates, hGates := cell.project(t, x, h)
+	gates := t.Add(xGates, hGates)
+	inputGate := t.Sigmoid(t.Narrow(gates, 1, 0, hiddenSize))
+	forgetGate := t.Sigmoid(t.Narrow(gates, 1, hiddenSize, hiddenSize))
+	cellGate := t.Tanh(t.Narrow(gates, 1, 2*hiddenSize, hiddenSize))
+	outputGate := t.Sigmoid(t.Narrow(gates, 1, 3*hiddenSize, hiddenSize))
+
+	cNext := t.Add(t.Mul(forgetGate, c), t.Mul(inputGate, cellGate))
+	hNext := t.Mul(outputGate, t.Tanh(cNext))
+	return hNext, cNext
+}
+
+// GRU is a gated recurrent unit layer with reset, update and new gates (in that order)
+type GRU struct {
+	*recurrent
+}
+
+// NewGRU creates a (possibly stacked and bidirectional) GRU
+func NewGRU(inputSize, hiddenSize, numLayers int, bidirectional, returnSequences bool) *GRU {
+	return &GRU{newRecurrent(3, inputSize, hiddenSize, numLayers, bidirectional, returnSequences, false, gruStep)}
+}
+
+func gruStep(t *autograd.Tape, cell *recurrentCell, hiddenSize int, x, h, c *autograd.Variable) (*autograd.Variable, *autograd.Variable) {
+	xGates, hGates := cell.project(t, x, h)
+	gate := func(g *autograd.Variable, i int) *autograd.Variable {
+		return t.Narrow(g, 1, i*hiddenSize, hiddenSize)
+	}
+	resetGate := t.Sigmoid(t.Add(gate(xGates, 0), gate(hGates, 0)))
+	updateGate := t.Sigmoid(t.Add(gate(xGates, 1), gate(hGates, 1)))
+	newGate := t.Tanh(t.Add(gate(xGates, 2), t.Mul(resetGate, gate(hGates, 2))))
+
+	// h' = (1 - z) * n + z * h = n + z * (h - n)
+	hNext := t.Add(newGate, t.Mul(updateGate, t.Sub(h, newGate)))
+	return hNext, c
+}
@@ -0,0 +1,49 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// Upsample modes
+const (
+	UpsampleNearest  = "nearest"  // repeat the nearest input pixel
+	UpsampleBilinear = "bilinear" // interpolate linearly along height and width
+)
+
+// Upsample enlarges the spatial dimensions of NCHW input by an integer factor
+type Upsample struct {
+	*Module
+	ScaleFactor int    // multiplier applied to height and width
+	Mode        string // UpsampleNearest or UpsampleBilinear
+}
+
+// NewUpsample creates a new upsampling layer with the given scale factor and mode
+func NewUpsample(scaleFactor int, mode string) *Upsample {
+	if scaleFactor <= 0 {
+		panic(fmt.Sprintf("Upsample: scale factor %d must be positive", scaleFactor))
+	}
+	if mode != UpsampleNearest && mode != UpsampleBilinear {
+		panic(fmt.Sprintf("Upsample: unknown mode %q, expected %q or %q", mode, UpsampleNearest, UpsampleBilinear))
+	}
+	u := &Upsample{
+		ScaleFactor: scaleFactor,
+		Mode:        mode,
+	}
+	u.Module = NewModule(u.forward)
+	return u
+}
+
+// forward resizes x (N, C, H, W) to (N, C, H*scale, W*scale)
+func (u *Upsample) forward(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	if len(shape) != 4 {
+		panic(fmt.Sprintf("Upsample: expected input (N, C, H, W), got shape %v", shape))
+	}
+	outH, outW := shape[2]*u.ScaleFactor, shape[3]*u.ScaleFactor
+	if u.Mode == UpsampleBilinear {
+		return t.UpsampleBilinear2D(x, outH, outW)
+	}
+	return t.UpsampleNearest2D(x, outH, outW)
+}
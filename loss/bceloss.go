@@ -0,0 +1,53 @@
+package loss
+
+import (
+	"fmt"
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// BCELoss implements binary cross-entropy on probabilities, e.g. the output of a Sigmoid layer.
+// Every element is an independent binary target, so multi-label problems use one column per label.
+type BCELoss struct {
+	elementwise
+	PosWeight []float64 // optional weight of positive targets per label (last dimension), nil for 1
+}
+
+// NewBCELoss creates a new binary cross-entropy loss function
+func NewBCELoss() *BCELoss {
+	return &BCELoss{}
+}
+
+// Forward computes -mean(posWeight * target * log(p) + (1 - target) * log(1 - p)),
+// clamping each log at -100 like PyTorch so saturated probabilities stay finite
+func (l *BCELoss) Forward(predictions, targets *tensor.Dense) float64 {
+	posWeight := positiveWeights("BCELoss", l.PosWeight, predictions)
+	return l.forward("BCELoss", predictions, targets, func(i int, p, y float64) float64 {
+		return -(posWeight(i)*y*math.Max(math.Log(p), -100) + (1-y)*math.Max(math.Log(1-p), -100))
+	})
+}
+
+// Backward computes gradient: -(posWeight * target / p - (1 - target) / (1 - p)) / total_elements
+func (l *BCELoss) Backward() *tensor.Dense {
+	posWeight := positiveWeights("BCELoss", l.PosWeight, l.Predictions)
+	return l.backward(func(i int, p, y float64) float64 {
+		// Keep the denominators away from zero for saturated probabilities
+		p = math.Min(math.Max(p, 1e-12), 1-1e-12)
+		return -(posWeight(i)*y/p - (1-y)/(1-p))
+	})
+}
+
+// positiveWeights returns the positive weight of every element of a binary loss,
+// broadcasting posWeight over the last dimension
+func positiveWeights(name string, posWeight []float64, predictions *tensor.Dense) func(i int) float64 {
+	if posWeight == nil {
+		return func(int) float64 { return 1 }
+	}
+	shape := predictions.Shape()
+	labels := shape[len(shape)-1]
+	if len(posWeight) != labels {
+		panic(fmt.Sprintf("%s: %d positive weights for %d labels", name, len(posWeight), labels))
+	}
+	return func(i int) float64 { return posWeight[i%labels] }
+}
@@ -0,0 +1,41 @@
+package loss
+
+import (
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// BCEWithLogitsLoss fuses a sigmoid and binary cross-entropy on raw logits.
+// It is more stable than Sigmoid followed by BCELoss; the model should end without a Sigmoid layer.
+type BCEWithLogitsLoss struct {
+	elementwise
+	PosWeight []float64 // optional weight of positive targets per label (last dimension), nil for 1
+}
+
+// NewBCEWithLogitsLoss creates a new logits-based binary cross-entropy loss function
+func NewBCEWithLogitsLoss() *BCEWithLogitsLoss {
+	return &BCEWithLogitsLoss{}
+}
+
+// Forward computes mean((1 - target) * x + (1 + (posWeight - 1) * target) * softplus(-x))
+func (l *BCEWithLogitsLoss) Forward(logits, targets *tensor.Dense) float64 {
+	posWeight := positiveWeights("BCEWithLogitsLoss", l.PosWeight, logits)
+	return l.forward("BCEWithLogitsLoss", logits, targets, func(i int, x, y float64) float64 {
+		return (1-y)*x + (1+(posWeight(i)-1)*y)*softplus(-x)
+	})
+}
+
+// Backward computes gradient: ((1 - target) - (1 + (posWeight - 1) * target) * sigmoid(-x)) / total_elements,
+// which is (sigmoid(x) - target) / total_elements without positive weights
+func (l *BCEWithLogitsLoss) Backward() *tensor.Dense {
+	posWeight := positiveWeights("BCEWithLogitsLoss", l.PosWeight, l.Predictions)
+	return l.backward(func(i int, x, y float64) float64 {
+		return (1 - y) - (1+(posWeight(i)-1)*y)/(1+math.Exp(x))
+	})
+}
+
+// softplus computes log(1 + exp(x)) without overflow
+func softplus(x float64) float64 {
+	return math.Max(x, 0) + math.Log1p(math.Exp(-math.Abs(x)))
+}
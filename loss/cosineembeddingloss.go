@@ -0,0 +1,54 @@
+package loss
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// CosineEmbeddingLoss learns whether two embeddings are similar (target 1) or dissimilar (target -1).
+// Predictions of shape (2N, D) stack the N first inputs followed by the N second inputs;
+// targets hold N labels.
+type CosineEmbeddingLoss struct {
+	graphLoss
+	Margin float64 // cosine similarity below which dissimilar pairs are not penalized
+}
+
+// NewCosineEmbeddingLoss creates a new cosine embedding loss with the given margin
+func NewCosineEmbeddingLoss(margin float64) *CosineEmbeddingLoss {
+	return &CosineEmbeddingLoss{Margin: margin}
+}
+
+// Forward computes mean(1 - cos(x1, x2)) for similar pairs and mean(max(0, cos(x1, x2) - margin)) for dissimilar ones
+func (l *CosineEmbeddingLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	n := splitBlocks("CosineEmbeddingLoss", predictions, 2)
+	targetData := targets.Data().([]float64)
+	if len(targetData) != n {
+		panic(fmt.Sprintf("CosineEmbeddingLoss: expected %d targets, got shape %v", n, targets.Shape()))
+	}
+	similar := make([]float64, n)
+	dissimilar := make([]float64, n)
+	for i, y := range targetData {
+		switch y {
+		case 1:
+			similar[i] = 1
+		case -1:
+			dissimilar[i] = 1
+		default:
+			panic(fmt.Sprintf("CosineEmbeddingLoss: target %v must be 1 or -1", y))
+		}
+	}
+
+	return l.forward("CosineEmbeddingLoss", predictions, n, func(t *autograd.Tape, pred *autograd.Variable) *autograd.Variable {
+		cos := rowSums(t, t.Mul(normalizeRows(t, t.Narrow(pred, 0, 0, n)), normalizeRows(t, t.Narrow(pred, 0, n, n))))
+		pull := t.Mul(t.AddScalar(t.Neg(cos), 1), constant(similar, n))
+		push := t.Mul(t.ReLU(t.AddScalar(cos, -l.Margin)), constant(dissimilar, n))
+		return t.Add(pull, push)
+	})
+}
+
+// Backward computes gradient w.r.t. the stacked embeddings
+func (l *CosineEmbeddingLoss) Backward() *tensor.Dense {
+	return l.backward()
+}
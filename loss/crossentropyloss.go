@@ -6,8 +6,12 @@ import (
 	"gorgonia.org/tensor"
 )
 
-// CrossEntropyLoss implements cross-entropy loss for multi-class classification
+// CrossEntropyLoss implements cross-entropy loss for multi-class classification on probabilities,
+// e.g. the output of a Softmax layer. Use CrossEntropyWithLogitsLoss to skip the Softmax layer
+// and take the faster, more stable fused path on raw logits.
+// On (N, T, C) input every sample's loss is the sum over its T rows, so the mean divides by N.
 type CrossEntropyLoss struct {
+	Options
 	Predictions *tensor.Dense // cached predictions for gradient computation
 	Targets     *tensor.Dense // cached targets (one-hot encoded)
 }
@@ -25,32 +29,26 @@ func (l *CrossEntropyLoss) Forward(predictions, targets *tensor.Dense) float64 {
 	predData := predictions.Data().([]float64)
 	targetData := targets.Data().([]float64)
 
-	loss := 0.0
+	shape := predictions.Shape()
+	cols := shape[len(shape)-1]
+	values := make([]float64, len(predData)/cols)
 	for i := range predData {
-		loss -= targetData[i] * math.Log(predData[i]+1e-9) // add epsilon for numerical stability
+		values[i/cols] -= targetData[i] * math.Log(predData[i]+1e-9) // add epsilon for numerical stability
 	}
-
-	shape := predictions.Shape()
-	rows := shape[0]
-	return loss / float64(rows)
+	return l.reduceSummed("CrossEntropyLoss", values, shape[0])
 }
 
-// Backward computes gradient: (predictions - targets) / batch_size
+// Backward computes gradient w.r.t. the probabilities: -targets / predictions / batch_size
 func (l *CrossEntropyLoss) Backward() *tensor.Dense {
 	predData := l.Predictions.Data().([]float64)
 	targetData := l.Targets.Data().([]float64)
 
 	gradData := make([]float64, len(predData))
 	for i := range predData {
-		gradData[i] = (predData[i] - targetData[i])
-	}
-
-	shape := l.Predictions.Shape()
-	rows := shape[0]
-	for i := range gradData {
-		gradData[i] /= float64(rows)
+		gradData[i] = -targetData[i] / (predData[i] + 1e-9)
 	}
+	l.scaleGrad(gradData)
 
-	grad := tensor.New(tensor.WithShape(shape...), tensor.WithBacking(gradData))
+	grad := tensor.New(tensor.WithShape(l.Predictions.Shape()...), tensor.WithBacking(gradData))
 	return grad
 }
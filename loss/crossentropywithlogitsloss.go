@@ -0,0 +1,145 @@
+package loss
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// CrossEntropyWithLogitsLoss fuses softmax and cross-entropy on raw logits of shape (..., classes).
+// The model should end without a Softmax layer. Targets are either class indices stored as
+// float64 values with one entry per logits row, or per-class probabilities (e.g. one-hot)
+// with the same shape as the logits.
+type CrossEntropyWithLogitsLoss struct {
+	Options
+	Weights        []float64     // optional per-class weights for imbalanced data (nil for uniform)
+	LabelSmoothing float64       // fraction of the target mass spread uniformly over all classes
+	Probabilities  *tensor.Dense // softmax of the cached logits for gradient computation
+	Targets        *tensor.Dense // cached targets
+	ignoreIndex    *int          // class index whose rows contribute neither loss nor gradient, nil for none
+	coefficients   []float64     // effective target weight of every logit
+}
+
+// NewCrossEntropyWithLogitsLoss creates a new logits-based cross-entropy loss function
+// without class weights, label smoothing or ignore index, the same as the zero value.
+// Unlike PyTorch no index is ignored by default; call SetIgnoreIndex(-100) for its behaviour.
+func NewCrossEntropyWithLogitsLoss() *CrossEntropyWithLogitsLoss {
+	return &CrossEntropyWithLogitsLoss{}
+}
+
+// SetIgnoreIndex makes rows whose class index target equals index contribute neither loss nor gradient
+func (l *CrossEntropyWithLogitsLoss) SetIgnoreIndex(index int) {
+	l.ignoreIndex = &index
+}
+
+// ClearIgnoreIndex makes every row contribute again
+func (l *CrossEntropyWithLogitsLoss) ClearIgnoreIndex() {
+	l.ignoreIndex = nil
+}
+
+// IgnoreIndex returns the ignored class index and whether one is set
+func (l *CrossEntropyWithLogitsLoss) IgnoreIndex() (int, bool) {
+	if l.ignoreIndex == nil {
+		return 0, false
+	}
+	return *l.ignoreIndex, true
+}
+
+// Forward computes the weighted mean of -sum(target * log_softmax(logits)) over all rows
+func (l *CrossEntropyWithLogitsLoss) Forward(logits, targets *tensor.Dense) float64 {
+	l.Targets = targets
+
+	shape := logits.Shape()
+	classes := shape[len(shape)-1]
+	if l.Weights != nil && len(l.Weights) != classes {
+		panic(fmt.Sprintf("CrossEntropyWithLogitsLoss: %d class weights for %d classes", len(l.Weights), classes))
+	}
+	logProbs := autograd.NewTape().LogSoftmax(autograd.NewVariable(logits, false)).Data()
+	var norm []float64
+	l.coefficients, norm = l.targetCoefficients(targets, len(logProbs)/classes, classes)
+
+	probData := make([]float64, len(logProbs))
+	values := make([]float64, len(logProbs)/classes)
+	for i, lp := range logProbs {
+		values[i/classes] -= l.coefficients[i] * lp
+		probData[i] = math.Exp(lp)
+	}
+	l.Probabilities = tensor.New(tensor.WithShape(shape...), tensor.WithBacking(probData))
+
+	return l.reduce("CrossEntropyWithLogitsLoss", values, norm, shape[0])
+}
+
+// Backward computes gradient w.r.t. the logits: (softmax(logits) * sum(coefficients) - coefficients) / normalizer,
+// which reduces to (softmax(logits) - targets) / batch_size for plain one-hot targets
+func (l *CrossEntropyWithLogitsLoss) Backward() *tensor.Dense {
+	probData := l.Probabilities.Data().([]float64)
+
+	shape := l.Probabilities.Shape()
+	cols := shape[len(shape)-1]
+	gradData := make([]float64, len(probData))
+	for start := 0; start < len(probData); start += cols {
+		rowSum := 0.0
+		for _, c := range l.coefficients[start : start+cols] {
+			rowSum += c
+		}
+		for i := start; i < start+cols; i++ {
+			gradData[i] = probData[i]*rowSum - l.coefficients[i]
+		}
+	}
+	l.scaleGrad(gradData)
+
+	return tensor.New(tensor.WithShape(shape...), tensor.WithBacking(gradData))
+}
+
+// targetCoefficients turns the targets into per-logit weights with smoothing and class weights applied,
+// and returns the weight of every row in the mean: its target class weight for class indices
+// (zero for ignored rows), or one for probability targets
+func (l *CrossEntropyWithLogitsLoss) targetCoefficients(targets *tensor.Dense, rows, classes int) ([]float64, []float64) {
+	targetData := targets.Data().([]float64)
+	classWeight := func(c int) float64 {
+		if l.Weights == nil {
+			return 1
+		}
+		return l.Weights[c]
+	}
+	smooth := l.LabelSmoothing / float64(classes)
+	coefficients := make([]float64, rows*classes)
+	norm := make([]float64, rows)
+
+	switch len(targetData) {
+	case rows:
+		for r, value := range targetData {
+			target := int(value)
+			if float64(target) != value {
+				panic(fmt.Sprintf("CrossEntropyWithLogitsLoss: class index %v is not an integer", value))
+			}
+			if l.ignoreIndex != nil && target == *l.ignoreIndex {
+				continue
+			}
+			if target < 0 || target >= classes {
+				panic(fmt.Sprintf("CrossEntropyWithLogitsLoss: class index %d out of range [0, %d)", target, classes))
+			}
+			row := coefficients[r*classes : (r+1)*classes]
+			for c := range row {
+				row[c] = smooth * classWeight(c)
+			}
+			row[target] += (1 - l.LabelSmoothing) * classWeight(target)
+			norm[r] = classWeight(target)
+		}
+		return coefficients, norm
+	case rows * classes:
+		for i, value := range targetData {
+			c := i % classes
+			coefficients[i] = ((1-l.LabelSmoothing)*value + smooth) * classWeight(c)
+		}
+		for r := range norm {
+			norm[r] = 1
+		}
+		return coefficients, norm
+	default:
+		panic(fmt.Sprintf("CrossEntropyWithLogitsLoss: targets of shape %v match neither %d class indices nor %d probabilities",
+			targets.Shape(), rows, rows*classes))
+	}
+}
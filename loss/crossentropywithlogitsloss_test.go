@@ -0,0 +1,69 @@
+package loss_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/loss"
+	"gorgonia.org/tensor"
+)
+
+// dense wraps data in a tensor of the given shape
+func dense(data []float64, shape ...int) *tensor.Dense {
+	return tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data))
+}
+
+// TestCrossEntropyWithLogitsClassZero checks that rows with class-index target 0 contribute to
+// loss and gradient unless 0 is explicitly set as the ignore index
+func TestCrossEntropyWithLogitsClassZero(t *testing.T) {
+	logits := []float64{2, 0.5, -1, 0.1, 0.2, 0.3}
+	targets := []float64{0, 2}
+
+	// -log_softmax at the target of every row, averaged over both rows
+	want := 0.0
+	for r, target := range targets {
+		row := logits[r*3 : r*3+3]
+		sum := 0.0
+		for _, v := range row {
+			sum += math.Exp(v)
+		}
+		want += (math.Log(sum) - row[int(target)]) / 2
+	}
+
+	for name, l := range map[string]*loss.CrossEntropyWithLogitsLoss{
+		"zero value":  {},
+		"constructor": loss.NewCrossEntropyWithLogitsLoss(),
+	} {
+		got := l.Forward(dense(append([]float64(nil), logits...), 2, 3), dense(targets, 2))
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s: loss %v, want %v", name, got, want)
+		}
+		grad := l.Backward().Data().([]float64)
+		if grad[0] >= 0 {
+			t.Errorf("%s: gradient of the class-0 target logit is %v, want negative", name, grad[0])
+		}
+	}
+
+	ignoring := loss.NewCrossEntropyWithLogitsLoss()
+	if _, ok := ignoring.IgnoreIndex(); ok {
+		t.Errorf("constructor: an ignore index is set by default")
+	}
+	ignoring.SetIgnoreIndex(0)
+	if index, ok := ignoring.IgnoreIndex(); !ok || index != 0 {
+		t.Errorf("IgnoreIndex() = %v, %v after SetIgnoreIndex(0), want 0, true", index, ok)
+	}
+	ignoring.Forward(dense(append([]float64(nil), logits...), 2, 3), dense(targets, 2))
+	for i, g := range ignoring.Backward().Data().([]float64)[:3] {
+		if g != 0 {
+			t.Errorf("ignored class-0 row: gradient[%d] = %v, want 0", i, g)
+		}
+	}
+
+	ignoring.ClearIgnoreIndex()
+	if _, ok := ignoring.IgnoreIndex(); ok {
+		t.Errorf("IgnoreIndex() still set after ClearIgnoreIndex")
+	}
+	if got := ignoring.Forward(dense(append([]float64(nil), logits...), 2, 3), dense(targets, 2)); math.Abs(got-want) > 1e-12 {
+		t.Errorf("after ClearIgnoreIndex: loss %v, want %v", got, want)
+	}
+}
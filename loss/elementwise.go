@@ -0,0 +1,45 @@
+package loss
+
+import (
+	"fmt"
+
+	"gorgonia.org/tensor"
+)
+
+// elementwise caches the inputs of losses built from a per-element function of the
+// prediction and target, and reduces that function and its derivative through Options
+type elementwise struct {
+	Options
+	Predictions *tensor.Dense // cached predictions for gradient computation
+	Targets     *tensor.Dense // cached target values
+}
+
+// forward caches the inputs and reduces f over all elements, where i is the flat element index
+func (e *elementwise) forward(name string, predictions, targets *tensor.Dense, f func(i int, pred, target float64) float64) float64 {
+	if predictions.Shape().TotalSize() != targets.Shape().TotalSize() {
+		panic(fmt.Sprintf("%s: predictions %v and targets %v differ in size", name, predictions.Shape(), targets.Shape()))
+	}
+	e.Predictions = predictions
+	e.Targets = targets
+
+	predData := predictions.Data().([]float64)
+	targetData := targets.Data().([]float64)
+	values := make([]float64, len(predData))
+	for i, p := range predData {
+		values[i] = f(i, p, targetData[i])
+	}
+	return e.reduce(name, values, nil, predictions.Shape()[0])
+}
+
+// backward returns df scaled by the reduction, where df is the derivative of f w.r.t. the prediction
+func (e *elementwise) backward(df func(i int, pred, target float64) float64) *tensor.Dense {
+	predData := e.Predictions.Data().([]float64)
+	targetData := e.Targets.Data().([]float64)
+
+	gradData := make([]float64, len(predData))
+	for i, p := range predData {
+		gradData[i] = df(i, p, targetData[i])
+	}
+	e.scaleGrad(gradData)
+	return tensor.New(tensor.WithShape(e.Predictions.Shape()...), tensor.WithBacking(gradData))
+}
@@ -0,0 +1,81 @@
+package loss
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// FocalLoss down-weights well-classified examples for extreme class imbalance:
+// -alpha_y * (1 - p_y)^gamma * log(p_y) with p = softmax(logits).
+// Logits have shape (..., classes); targets are class indices with one entry per row
+// or per-class probabilities with the same shape as the logits.
+type FocalLoss struct {
+	graphLoss
+	Gamma float64   // focusing parameter; 0 recovers cross-entropy
+	Alpha []float64 // optional per-class balancing weights, nil for 1
+}
+
+// NewFocalLoss creates a new focal loss with the given focusing parameter
+func NewFocalLoss(gamma float64) *FocalLoss {
+	if gamma < 0 {
+		panic(fmt.Sprintf("FocalLoss: gamma %v must be non-negative", gamma))
+	}
+	return &FocalLoss{Gamma: gamma}
+}
+
+// Forward computes mean(-sum(alpha * target * (1 - p)^gamma * log(p))) over all rows
+func (l *FocalLoss) Forward(logits, targets *tensor.Dense) float64 {
+	shape := logits.Shape()
+	classes := shape[len(shape)-1]
+	rows := shape.TotalSize() / classes
+	if l.Alpha != nil && len(l.Alpha) != classes {
+		panic(fmt.Sprintf("FocalLoss: %d alpha weights for %d classes", len(l.Alpha), classes))
+	}
+
+	weights := classProbabilities("FocalLoss", targets, rows, classes)
+	for i := range weights {
+		if l.Alpha != nil {
+			weights[i] *= l.Alpha[i%classes]
+		}
+	}
+
+	return l.forward("FocalLoss", logits, shape[0], func(t *autograd.Tape, pred *autograd.Variable) *autograd.Variable {
+		logProbs := t.LogSoftmax(t.Reshape(pred, rows, classes))
+		weighted := t.Mul(logProbs, constant(weights, rows, classes))
+		if l.Gamma != 0 {
+			// (1 - p)^gamma down-weights confidently correct rows; with gamma 0 it is plain cross-entropy
+			weighted = t.Mul(t.Pow(t.AddScalar(t.Neg(t.Exp(logProbs)), 1), l.Gamma), weighted)
+		}
+		return t.Neg(rowSums(t, weighted))
+	})
+}
+
+// Backward computes gradient w.r.t. the logits
+func (l *FocalLoss) Backward() *tensor.Dense {
+	return l.backward()
+}
+
+// classProbabilities expands class-index targets (one per row) into one-hot rows,
+// or copies per-class probability targets that already have rows*classes entries
+func classProbabilities(name string, targets *tensor.Dense, rows, classes int) []float64 {
+	targetData := targets.Data().([]float64)
+	switch len(targetData) {
+	case rows:
+		probs := make([]float64, rows*classes)
+		for r, value := range targetData {
+			target := int(value)
+			if float64(target) != value || target < 0 || target >= classes {
+				panic(fmt.Sprintf("%s: class index %v out of range [0, %d)", name, value, classes))
+			}
+			probs[r*classes+target] = 1
+		}
+		return probs
+	case rows * classes:
+		return append([]float64(nil), targetData...)
+	default:
+		panic(fmt.Sprintf("%s: targets of shape %v match neither %d class indices nor %d probabilities",
+			name, targets.Shape(), rows, rows*classes))
+	}
+}
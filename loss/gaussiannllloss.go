@@ -0,0 +1,106 @@
+package loss
+
+import (
+	"fmt"
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// GaussianNLLLoss is the negative log-likelihood of targets under a Gaussian whose mean and
+// variance are both predicted, for heteroscedastic regression. With targets of shape (N, D),
+// predictions of shape (N, 2D) hold the D means followed by the D variances, or (N, D+1) for a
+// single variance shared by the whole sample.
+type GaussianNLLLoss struct {
+	Options
+	Full        bool          // whether to add the constant 0.5 * log(2 * pi)
+	Eps         float64       // lower clamp on the variance for stability
+	LogVariance bool          // whether the variance columns hold log-variances, which need no positivity constraint
+	Predictions *tensor.Dense // cached means and variances for gradient computation
+	Targets     *tensor.Dense // cached target values
+}
+
+// NewGaussianNLLLoss creates a new Gaussian negative log-likelihood loss function
+func NewGaussianNLLLoss() *GaussianNLLLoss {
+	return &GaussianNLLLoss{Eps: 1e-6}
+}
+
+// Forward computes mean(0.5 * (log(var) + (target - mean)^2 / var))
+func (l *GaussianNLLLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	l.Predictions = predictions
+	l.Targets = targets
+
+	predData := predictions.Data().([]float64)
+	targetData := targets.Data().([]float64)
+	samples, dims, cols := l.layout(predictions, targets)
+
+	values := make([]float64, len(targetData))
+	for i, y := range targetData {
+		mean, variance, logVariance := l.moments(predData, i, dims, cols)
+		d := y - mean
+		values[i] = 0.5 * (logVariance + d*d/variance)
+		if l.Full {
+			values[i] += 0.5 * math.Log(2*math.Pi)
+		}
+	}
+	return l.reduce("GaussianNLLLoss", values, nil, samples)
+}
+
+// Backward computes gradient: (mean - target) / var for the means and
+// 0.5 * (1 / var - (target - mean)^2 / var^2) for the variances, scaled by the reduction
+func (l *GaussianNLLLoss) Backward() *tensor.Dense {
+	predData := l.Predictions.Data().([]float64)
+	targetData := l.Targets.Data().([]float64)
+	_, dims, cols := l.layout(l.Predictions, l.Targets)
+
+	gradData := make([]float64, len(predData))
+	for i, y := range targetData {
+		mean, variance, _ := l.moments(predData, i, dims, cols)
+		d := y - mean
+		r, j := i/dims, i%dims
+		gradData[r*cols+j] -= l.scale[i] * d / variance
+		gradVariance := 0.5 * (1/variance - d*d/(variance*variance))
+		if l.LogVariance {
+			gradVariance *= variance
+		}
+		gradData[r*cols+varianceColumn(j, dims, cols)] += l.scale[i] * gradVariance
+	}
+	return tensor.New(tensor.WithShape(l.Predictions.Shape()...), tensor.WithBacking(gradData))
+}
+
+// layout checks the prediction shape against the targets and returns the number of samples,
+// the number of target values per sample and the number of prediction columns
+func (l *GaussianNLLLoss) layout(predictions, targets *tensor.Dense) (samples, dims, cols int) {
+	samples = predictions.Shape()[0]
+	dims = targets.Shape().TotalSize() / samples
+	cols = predictions.Shape().TotalSize() / samples
+	if dims*samples != targets.Shape().TotalSize() || (cols != 2*dims && cols != dims+1) {
+		panic(fmt.Sprintf("GaussianNLLLoss: predictions %v must hold a mean and a variance for targets %v",
+			predictions.Shape(), targets.Shape()))
+	}
+	return samples, dims, cols
+}
+
+// moments returns the mean, the clamped variance and its logarithm predicted for target i
+func (l *GaussianNLLLoss) moments(predData []float64, i, dims, cols int) (mean, variance, logVariance float64) {
+	r, j := i/dims, i%dims
+	mean = predData[r*cols+j]
+	v := predData[r*cols+varianceColumn(j, dims, cols)]
+	if l.LogVariance {
+		return mean, math.Exp(v), v
+	}
+	if v < 0 {
+		panic(fmt.Sprintf("GaussianNLLLoss: variance %v must be non-negative", v))
+	}
+	// Like PyTorch, the clamp is invisible to the gradient
+	variance = math.Max(v, l.Eps)
+	return mean, variance, math.Log(variance)
+}
+
+// varianceColumn returns the prediction column holding the variance of target column j
+func varianceColumn(j, dims, cols int) int {
+	if cols == dims+1 {
+		return dims
+	}
+	return dims + j
+}
@@ -0,0 +1,370 @@
+package loss_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/loss"
+	"gorgonia.org/tensor"
+)
+
+// randomDense returns a tensor of the given shape with standard normal entries
+func randomDense(rng *rand.Rand, shape ...int) *tensor.Dense {
+	size := 1
+	for _, d := range shape {
+		size *= d
+	}
+	data := make([]float64, size)
+	for i := range data {
+		data[i] = rng.NormFloat64()
+	}
+	return dense(data, shape...)
+}
+
+// logSoftmaxRows returns the log-softmax of every row of a random (rows, cols) tensor
+func logSoftmaxRows(rng *rand.Rand, rows, cols int) *tensor.Dense {
+	x := randomDense(rng, rows, cols)
+	data := x.Data().([]float64)
+	for r := 0; r < rows; r++ {
+		row := data[r*cols : (r+1)*cols]
+		sum := 0.0
+		for _, v := range row {
+			sum += math.Exp(v)
+		}
+		for j := range row {
+			row[j] -= math.Log(sum)
+		}
+	}
+	return x
+}
+
+// checkGradient compares Backward with central finite differences of Forward
+func checkGradient(t *testing.T, l loss.Loss, pred, target *tensor.Dense) {
+	t.Helper()
+	const h, tolerance = 1e-6, 1e-6
+
+	l.Forward(pred, target)
+	grad := append([]float64(nil), l.Backward().Data().([]float64)...)
+	predData := pred.Data().([]float64)
+	for i := range predData {
+		orig := predData[i]
+		predData[i] = orig + h
+		plus := l.Forward(pred, target)
+		predData[i] = orig - h
+		minus := l.Forward(pred, target)
+		predData[i] = orig
+
+		numeric := (plus - minus) / (2 * h)
+		if !(math.Abs(numeric-grad[i]) <= tolerance) { // also rejects NaN
+			t.Fatalf("gradient[%d] = %v, finite differences give %v", i, grad[i], numeric)
+		}
+	}
+}
+
+func TestRegressionLossGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	// errors of exactly -delta and +delta sit on the switch between the quadratic and linear pieces
+	boundaryPred := dense([]float64{1.5, -0.5, -2.25, 3}, 4)
+	boundaryTarget := dense([]float64{0, 1, -2, 0.5}, 4)
+
+	cases := []struct {
+		name   string
+		l      loss.Loss
+		pred   *tensor.Dense
+		target *tensor.Dense
+	}{
+		{"L1", loss.NewL1Loss(), randomDense(rng, 4, 3), randomDense(rng, 4, 3)},
+		{"Huber", loss.NewHuberLoss(1), randomDense(rng, 4, 3), randomDense(rng, 4, 3)},
+		{"Huber small delta", loss.NewHuberLoss(0.3), randomDense(rng, 4, 3), randomDense(rng, 4, 3)},
+		{"Huber at delta", loss.NewHuberLoss(1.5), boundaryPred, boundaryTarget},
+		{"SmoothL1", loss.NewSmoothL1Loss(1), randomDense(rng, 4, 3), randomDense(rng, 4, 3)},
+		{"SmoothL1 small beta", loss.NewSmoothL1Loss(0.3), randomDense(rng, 4, 3), randomDense(rng, 4, 3)},
+		{"SmoothL1 at beta", loss.NewSmoothL1Loss(1.5), boundaryPred, boundaryTarget},
+		{"LogCosh", loss.NewLogCoshLoss(), randomDense(rng, 4, 3), randomDense(rng, 4, 3)},
+		{"LogCosh large errors", loss.NewLogCoshLoss(), dense([]float64{30, -40, 0.5, 400}, 4), dense([]float64{0, 0, 0, 0}, 4)},
+		{"Quantile", loss.NewQuantileLoss(0.1, 0.5, 0.9), randomDense(rng, 5, 3), randomDense(rng, 5)},
+		{"Quantile column targets", loss.NewQuantileLoss(0.25), randomDense(rng, 5, 1), randomDense(rng, 5, 1)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checkGradient(t, c.l, c.pred, c.target)
+		})
+	}
+}
+
+// gaussianPredictions returns (samples, dims + varianceCols) predictions holding standard normal
+// means followed by variances in [0.5, 2), or standard normal log-variances if logVariance is set
+func gaussianPredictions(rng *rand.Rand, samples, dims, varianceCols int, logVariance bool) *tensor.Dense {
+	cols := dims + varianceCols
+	pred := randomDense(rng, samples, cols)
+	data := pred.Data().([]float64)
+	for i := range data {
+		if i%cols >= dims && !logVariance {
+			data[i] = 0.5 + 1.5*rng.Float64()
+		}
+	}
+	return pred
+}
+
+func TestProbabilisticLossGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	full := loss.NewGaussianNLLLoss()
+	full.Full = true
+	logVariance := loss.NewGaussianNLLLoss()
+	logVariance.LogVariance = true
+	// the clamp only moves variances below Eps, so these variances in [0.5, 2) stay put
+	clampInactive := loss.NewGaussianNLLLoss()
+	clampInactive.Eps = 0.4
+	weighted := loss.NewGaussianNLLLoss()
+	weighted.Reduction = loss.ReductionSum
+	weighted.SampleWeights = dense([]float64{1, 0.5, 2, 1}, 4)
+	weighted.Mask = dense([]float64{1, 0, 1, 1, 1, 1, 0, 1}, 4, 2)
+
+	rates := loss.NewPoissonNLLLoss()
+	rates.LogInput = false
+	fullLog := loss.NewPoissonNLLLoss()
+	fullLog.Full = true
+	fullRates := loss.NewPoissonNLLLoss()
+	fullRates.LogInput, fullRates.Full = false, true
+	// counts on both sides of the Stirling term's target > 1 condition
+	counts := dense([]float64{0, 1, 2, 3, 5, 0, 1, 4, 7, 2, 0, 1}, 4, 3)
+	positiveRates := func() *tensor.Dense {
+		pred := randomDense(rng, 4, 3)
+		data := pred.Data().([]float64)
+		for i := range data {
+			data[i] = 0.5 + 3*rng.Float64()
+		}
+		return pred
+	}
+
+	cases := []struct {
+		name   string
+		l      loss.Loss
+		pred   *tensor.Dense
+		target *tensor.Dense
+	}{
+		{"GaussianNLL", loss.NewGaussianNLLLoss(), gaussianPredictions(rng, 4, 3, 3, false), randomDense(rng, 4, 3)},
+		{"GaussianNLL full", full, gaussianPredictions(rng, 4, 3, 3, false), randomDense(rng, 4, 3)},
+		{"GaussianNLL shared variance", loss.NewGaussianNLLLoss(), gaussianPredictions(rng, 4, 3, 1, false), randomDense(rng, 4, 3)},
+		{"GaussianNLL log variance", logVariance, gaussianPredictions(rng, 4, 2, 2, true), randomDense(rng, 4, 2)},
+		{"GaussianNLL large eps", clampInactive, gaussianPredictions(rng, 4, 2, 2, false), randomDense(rng, 4, 2)},
+		{"GaussianNLL weighted masked sum", weighted, gaussianPredictions(rng, 4, 2, 2, false), randomDense(rng, 4, 2)},
+		{"PoissonNLL log input", loss.NewPoissonNLLLoss(), randomDense(rng, 4, 3), counts},
+		{"PoissonNLL log input full", fullLog, randomDense(rng, 4, 3), counts},
+		{"PoissonNLL rates", rates, positiveRates(), counts},
+		{"PoissonNLL rates full", fullRates, positiveRates(), counts},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checkGradient(t, c.l, c.pred, c.target)
+		})
+	}
+}
+
+// TestGaussianNLLClamp checks that a variance below Eps is evaluated at Eps and, as in PyTorch,
+// passes the gradient at Eps straight through to the predicted variance
+func TestGaussianNLLClamp(t *testing.T) {
+	const eps, mean, target = 0.25, 1.0, 0.5
+	l := loss.NewGaussianNLLLoss()
+	l.Eps = eps
+
+	for _, variance := range []float64{0, 0.1} {
+		got := l.Forward(dense([]float64{mean, variance}, 1, 2), dense([]float64{target}, 1))
+		d := target - mean
+		if want := 0.5 * (math.Log(eps) + d*d/eps); math.Abs(got-want) > 1e-12 {
+			t.Errorf("variance %v: loss %v, want %v", variance, got, want)
+		}
+		grad := l.Backward().Data().([]float64)
+		want := []float64{-d / eps, 0.5 * (1/eps - d*d/(eps*eps))}
+		for i := range want {
+			if math.Abs(grad[i]-want[i]) > 1e-12 {
+				t.Errorf("variance %v: gradient %v, want %v", variance, grad, want)
+				break
+			}
+		}
+	}
+}
+
+// TestFocalLossSaturated checks that a confidently correct row, where 1 - p is exactly zero,
+// yields a finite gradient: plain cross-entropy's softmax - target at gamma 0, and zero for
+// that row at gamma 2
+func TestFocalLossSaturated(t *testing.T) {
+	logits := []float64{100, 0, 0.5, -0.3}
+	targets := []float64{0, 1}
+
+	// softmax - one-hot of the second row, over 2 rows; the first row's is below 1e-40
+	e := math.Exp(-0.8)
+	unsaturated := []float64{1 / (1 + e) / 2, -1 / (1 + e) / 2}
+
+	for _, gamma := range []float64{0, 2} {
+		l := loss.NewFocalLoss(gamma)
+		if v := l.Forward(dense(append([]float64(nil), logits...), 2, 2), dense(targets, 2)); math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Fatalf("gamma %v: loss %v", gamma, v)
+		}
+		grad := l.Backward().Data().([]float64)
+		for i, g := range grad {
+			if math.IsNaN(g) || math.IsInf(g, 0) {
+				t.Fatalf("gamma %v: gradient %v is not finite", gamma, grad)
+			}
+			if i < 2 && math.Abs(g) > 1e-12 {
+				t.Errorf("gamma %v: saturated row gradient[%d] = %v, want 0", gamma, i, g)
+			}
+		}
+		if gamma == 0 {
+			for i, want := range unsaturated {
+				if math.Abs(grad[2+i]-want) > 1e-12 {
+					t.Errorf("gamma 0: gradient[%d] = %v, want the cross-entropy gradient %v", 2+i, grad[2+i], want)
+				}
+			}
+		}
+		checkGradient(t, l, dense(append([]float64(nil), logits...), 2, 2), dense(targets, 2))
+	}
+}
+
+func TestDistributionAndMetricLossGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	logTarget := loss.NewKLDivLoss()
+	logTarget.LogTarget = true
+	alphaFocal := loss.NewFocalLoss(2)
+	alphaFocal.Alpha = []float64{0.25, 0.5, 0.25}
+
+	cases := []struct {
+		name   string
+		l      loss.Loss
+		pred   *tensor.Dense
+		target *tensor.Dense
+	}{
+		{"KLDiv", loss.NewKLDivLoss(), logSoftmaxRows(rng, 4, 3), dense([]float64{0.2, 0.3, 0.5, 1, 0, 0, 0.1, 0.8, 0.1, 0.6, 0.2, 0.2}, 4, 3)},
+		{"KLDiv log target", logTarget, logSoftmaxRows(rng, 4, 3), logSoftmaxRows(rng, 4, 3)},
+		{"Focal gamma 0", loss.NewFocalLoss(0), randomDense(rng, 4, 3), dense([]float64{0, 2, 1, 1}, 4)},
+		{"Focal gamma 2 alpha", alphaFocal, randomDense(rng, 4, 3), dense([]float64{0, 2, 1, 1}, 4)},
+		{"Focal one-hot", loss.NewFocalLoss(1.5), randomDense(rng, 4, 3), dense([]float64{1, 0, 0, 0, 1, 0, 0, 0, 1, 1, 0, 0}, 4, 3)},
+		{"TripletMargin", loss.NewTripletMarginLoss(1), randomDense(rng, 12, 5), nil},
+		{"TripletMargin all active", loss.NewTripletMarginLoss(10), randomDense(rng, 12, 5), nil},
+		{"CosineEmbedding", loss.NewCosineEmbeddingLoss(0), cosinePairs(), dense([]float64{1, -1, 1, -1}, 4)},
+		{"CosineEmbedding margin", loss.NewCosineEmbeddingLoss(0.5), cosinePairs(), dense([]float64{-1, 1, -1, 1}, 4)},
+		{"InfoNCE temperature 0.1", loss.NewInfoNCELoss(0.1), randomDense(rng, 8, 5), nil},
+		{"InfoNCE temperature 1", loss.NewInfoNCELoss(1), randomDense(rng, 8, 5), nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checkGradient(t, c.l, c.pred, c.target)
+		})
+	}
+}
+
+// cosinePairs returns 4 pairs stacked as (8, 3) with cosine similarities of 1, about 0.71, 0 and -1
+func cosinePairs() *tensor.Dense {
+	return dense([]float64{
+		1, 2, 3,
+		1, 1, 0,
+		1, 0, 0,
+		-1, 0, 0,
+		2, 4, 6,
+		1, 0, 0,
+		0, 1, 0,
+		1, 0, 0,
+	}, 8, 3)
+}
+
+// TestTripletMarginHinge checks both sides of the hinge: triplets whose negative is already far
+// enough away contribute neither loss nor gradient, the others contribute d(a, p) - d(a, n) + margin
+func TestTripletMarginHinge(t *testing.T) {
+	// two triplets in 2D: the first one satisfied, the second one violated
+	pred := dense([]float64{
+		0, 0, 0, 0, // anchors (0, 0) and (0, 0)
+		1, 0, 2, 0, // positives (1, 0) and (2, 0)
+		5, 0, 1, 0, // negatives (5, 0) and (1, 0)
+	}, 6, 2)
+	var l loss.Loss = loss.NewTripletMarginLoss(1)
+	got := l.Forward(pred, nil)
+
+	dist := func(ax, ay, bx, by float64) float64 {
+		return math.Hypot(ax-bx+1e-6, ay-by+1e-6)
+	}
+	want := (0 + (dist(0, 0, 2, 0) - dist(0, 0, 1, 0) + 1)) / 2
+	if math.Abs(got-want) > 1e-12 {
+		t.Fatalf("loss %v, want %v", got, want)
+	}
+
+	grad := l.Backward().Data().([]float64)
+	for _, i := range []int{0, 1, 4, 5, 8, 9} {
+		if grad[i] != 0 {
+			t.Errorf("satisfied triplet: gradient[%d] = %v, want 0", i, grad[i])
+		}
+	}
+	checkGradient(t, l, pred, nil)
+}
+
+// TestCosineEmbeddingMargin checks that dissimilar pairs are penalized by cos - margin only
+// above the margin, while similar pairs are always penalized by 1 - cos
+func TestCosineEmbeddingMargin(t *testing.T) {
+	pred := cosinePairs()
+	cos := []float64{1, 1 / math.Sqrt2, 0, -1}
+	for _, margin := range []float64{-0.5, 0, 0.5} {
+		for _, targets := range [][]float64{{1, 1, 1, 1}, {-1, -1, -1, -1}} {
+			var l loss.Loss = loss.NewCosineEmbeddingLoss(margin)
+			got := l.Forward(pred, dense(targets, 4))
+
+			want := 0.0
+			for i, c := range cos {
+				if targets[i] == 1 {
+					want += (1 - c) / 4
+				} else {
+					want += math.Max(0, c-margin) / 4
+				}
+			}
+			if math.Abs(got-want) > 1e-9 {
+				t.Errorf("margin %v targets %v: loss %v, want %v", margin, targets, got, want)
+			}
+		}
+	}
+}
+
+// TestInfoNCETemperature checks the loss value against a direct computation at several temperatures
+func TestInfoNCETemperature(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	const n, d = 3, 4
+	pred := randomDense(rng, 2*n, d)
+	data := pred.Data().([]float64)
+
+	normalized := func(row int) []float64 {
+		v := data[row*d : (row+1)*d]
+		norm := 0.0
+		for _, x := range v {
+			norm += x * x
+		}
+		out := make([]float64, d)
+		for j, x := range v {
+			out[j] = x / math.Sqrt(norm)
+		}
+		return out
+	}
+
+	for _, temperature := range []float64{0.05, 0.1, 0.5, 2} {
+		want := 0.0
+		for i := 0; i < n; i++ {
+			q := normalized(i)
+			logits := make([]float64, n)
+			sum := 0.0
+			for j := 0; j < n; j++ {
+				k := normalized(n + j)
+				for c := range q {
+					logits[j] += q[c] * k[c] / temperature
+				}
+				sum += math.Exp(logits[j])
+			}
+			want += (math.Log(sum) - logits[i]) / n
+		}
+
+		var l loss.Loss = loss.NewInfoNCELoss(temperature)
+		if got := l.Forward(pred, nil); math.Abs(got-want) > 1e-9 {
+			t.Errorf("temperature %v: loss %v, want %v", temperature, got, want)
+		}
+		checkGradient(t, l, pred, nil)
+	}
+}
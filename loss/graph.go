@@ -0,0 +1,63 @@
+package loss
+
+import (
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// graphLoss records a loss on an autograd tape so that its gradient comes from replaying
+// the tape, which suits losses with couplings between elements such as normalizations
+type graphLoss struct {
+	Options
+	Predictions *tensor.Dense      // cached predictions for gradient computation
+	tape        *autograd.Tape     // operations recorded by the last forward pass
+	pred        *autograd.Variable // differentiable view of the predictions
+	values      *autograd.Variable // element losses before reduction
+}
+
+// forward records build on a fresh tape and reduces the element losses it returns over samples
+func (g *graphLoss) forward(name string, predictions *tensor.Dense, samples int,
+	build func(t *autograd.Tape, pred *autograd.Variable) *autograd.Variable) float64 {
+	g.Predictions = predictions
+	g.tape = autograd.NewTape()
+	g.pred = autograd.NewVariable(predictions, true)
+	g.values = build(g.tape, g.pred)
+	return g.reduce(name, g.values.Data(), nil, samples)
+}
+
+// backward replays the tape seeded with the reduction scale of every element loss
+func (g *graphLoss) backward() *tensor.Dense {
+	g.pred.Grad = nil
+	seed := tensor.New(tensor.WithShape(g.values.Shape()...), tensor.WithBacking(append([]float64(nil), g.scale...)))
+	g.tape.Backward(g.values, seed)
+	if g.pred.Grad == nil {
+		shape := g.pred.Shape()
+		return tensor.New(tensor.WithShape(shape...), tensor.WithBacking(make([]float64, shape.TotalSize())))
+	}
+	return g.pred.Grad
+}
+
+// constant wraps data as a variable that receives no gradient
+func constant(data []float64, shape ...int) *autograd.Variable {
+	return autograd.NewVariable(tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data)), false)
+}
+
+// rowSums sums every row of a 2D variable into a vector
+func rowSums(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	shape := x.Shape()
+	ones := make([]float64, shape[1])
+	for i := range ones {
+		ones[i] = 1
+	}
+	return t.Reshape(t.MatMul(x, constant(ones, shape[1], 1)), shape[0])
+}
+
+// scaleRows multiplies every row of a 2D variable by the matching entry of s
+func scaleRows(t *autograd.Tape, x, s *autograd.Variable) *autograd.Variable {
+	return t.Transpose(t.MulRow(t.Transpose(x), s))
+}
+
+// normalizeRows scales every row of a 2D variable to unit L2 norm
+func normalizeRows(t *autograd.Tape, x *autograd.Variable) *autograd.Variable {
+	return scaleRows(t, x, t.Pow(t.AddScalar(rowSums(t, t.Square(x)), 1e-12), -0.5))
+}
@@ -0,0 +1,73 @@
+package loss
+
+import (
+	"fmt"
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// HuberLoss is quadratic for errors up to Delta and linear beyond it,
+// combining the smoothness of MSE with the outlier robustness of L1
+type HuberLoss struct {
+	elementwise
+	Delta float64 // error size at which the loss switches from quadratic to linear
+}
+
+// NewHuberLoss creates a new Huber loss function with the given threshold
+func NewHuberLoss(delta float64) *HuberLoss {
+	if delta <= 0 {
+		panic(fmt.Sprintf("HuberLoss: delta %v must be positive", delta))
+	}
+	return &HuberLoss{Delta: delta}
+}
+
+// Forward computes mean(0.5 * d^2) for |d| <= delta and mean(delta * (|d| - 0.5 * delta)) otherwise
+func (l *HuberLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	return l.forward("HuberLoss", predictions, targets, func(_ int, p, y float64) float64 {
+		return huber(p-y, l.Delta)
+	})
+}
+
+// Backward computes gradient: clamp(predictions - targets, -delta, delta) / total_elements
+func (l *HuberLoss) Backward() *tensor.Dense {
+	return l.backward(func(_ int, p, y float64) float64 {
+		return math.Max(-l.Delta, math.Min(l.Delta, p-y))
+	})
+}
+
+// SmoothL1Loss is the Huber loss divided by Beta, so its linear region has slope one like L1Loss
+type SmoothL1Loss struct {
+	elementwise
+	Beta float64 // error size at which the loss switches from quadratic to linear
+}
+
+// NewSmoothL1Loss creates a new smooth L1 loss function with the given threshold
+func NewSmoothL1Loss(beta float64) *SmoothL1Loss {
+	if beta <= 0 {
+		panic(fmt.Sprintf("SmoothL1Loss: beta %v must be positive", beta))
+	}
+	return &SmoothL1Loss{Beta: beta}
+}
+
+// Forward computes mean(0.5 * d^2 / beta) for |d| < beta and mean(|d| - 0.5 * beta) otherwise
+func (l *SmoothL1Loss) Forward(predictions, targets *tensor.Dense) float64 {
+	return l.forward("SmoothL1Loss", predictions, targets, func(_ int, p, y float64) float64 {
+		return huber(p-y, l.Beta) / l.Beta
+	})
+}
+
+// Backward computes gradient: clamp((predictions - targets) / beta, -1, 1) / total_elements
+func (l *SmoothL1Loss) Backward() *tensor.Dense {
+	return l.backward(func(_ int, p, y float64) float64 {
+		return math.Max(-1, math.Min(1, (p-y)/l.Beta))
+	})
+}
+
+// huber evaluates the Huber function of the error d
+func huber(d, delta float64) float64 {
+	if math.Abs(d) <= delta {
+		return 0.5 * d * d
+	}
+	return delta * (math.Abs(d) - 0.5*delta)
+}
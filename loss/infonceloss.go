@@ -0,0 +1,46 @@
+package loss
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// InfoNCELoss is a batch contrastive loss: every query must pick out its own key among all keys
+// in the batch, the others acting as negatives. Predictions of shape (2N, D) stack N queries
+// followed by the N matching keys; similarities are cosine similarities divided by Temperature.
+// Targets are unused.
+type InfoNCELoss struct {
+	graphLoss
+	Temperature float64 // softmax temperature; smaller values sharpen the distribution
+}
+
+// NewInfoNCELoss creates a new InfoNCE loss with the given temperature
+func NewInfoNCELoss(temperature float64) *InfoNCELoss {
+	if temperature <= 0 {
+		panic(fmt.Sprintf("InfoNCELoss: temperature %v must be positive", temperature))
+	}
+	return &InfoNCELoss{Temperature: temperature}
+}
+
+// Forward computes mean(-log_softmax(cos(q_i, k_j) / temperature)_ii) over the queries
+func (l *InfoNCELoss) Forward(predictions, targets *tensor.Dense) float64 {
+	n := splitBlocks("InfoNCELoss", predictions, 2)
+	identity := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		identity[i*n+i] = 1
+	}
+
+	return l.forward("InfoNCELoss", predictions, n, func(t *autograd.Tape, pred *autograd.Variable) *autograd.Variable {
+		queries := normalizeRows(t, t.Narrow(pred, 0, 0, n))
+		keys := normalizeRows(t, t.Narrow(pred, 0, n, n))
+		logits := t.Scale(t.MatMul(queries, t.Transpose(keys)), 1/l.Temperature)
+		return t.Neg(rowSums(t, t.Mul(t.LogSoftmax(logits), constant(identity, n, n))))
+	})
+}
+
+// Backward computes gradient w.r.t. the stacked queries and keys
+func (l *InfoNCELoss) Backward() *tensor.Dense {
+	return l.backward()
+}
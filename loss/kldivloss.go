@@ -0,0 +1,58 @@
+package loss
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// KLDivLoss implements the Kullback-Leibler divergence KL(target || prediction) for distillation.
+// Predictions are log-probabilities over the last dimension, e.g. from a LogSoftmax layer;
+// targets are probabilities, or log-probabilities when LogTarget is set.
+// Each row of classes is one element loss, so mean reduction averages over rows (PyTorch's batchmean).
+type KLDivLoss struct {
+	graphLoss
+	LogTarget bool // whether targets are given as log-probabilities
+}
+
+// NewKLDivLoss creates a new KL divergence loss function
+func NewKLDivLoss() *KLDivLoss {
+	return &KLDivLoss{}
+}
+
+// Forward computes mean(sum(target * (log(target) - prediction)))
+func (l *KLDivLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	shape := predictions.Shape()
+	classes := shape[len(shape)-1]
+	rows := shape.TotalSize() / classes
+
+	targetData := targets.Data().([]float64)
+	if len(targetData) != shape.TotalSize() {
+		panic(fmt.Sprintf("KLDivLoss: predictions %v and targets %v differ in size", shape, targets.Shape()))
+	}
+	probs := make([]float64, len(targetData))
+	entropy := make([]float64, rows) // sum(target * log(target)) of every row, zero where target is zero
+	for i, v := range targetData {
+		p, logP := v, math.Log(v)
+		if l.LogTarget {
+			p, logP = math.Exp(v), v
+		}
+		probs[i] = p
+		if p > 0 {
+			entropy[i/classes] += p * logP
+		}
+	}
+
+	return l.forward("KLDivLoss", predictions, shape[0], func(t *autograd.Tape, pred *autograd.Variable) *autograd.Variable {
+		rowsView := t.Reshape(pred, rows, classes)
+		crossEntropy := rowSums(t, t.Mul(rowsView, constant(probs, rows, classes)))
+		return t.Sub(constant(entropy, rows), crossEntropy)
+	})
+}
+
+// Backward computes gradient w.r.t. the predicted log-probabilities
+func (l *KLDivLoss) Backward() *tensor.Dense {
+	return l.backward()
+}
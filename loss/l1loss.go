@@ -0,0 +1,37 @@
+package loss
+
+import (
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// L1Loss implements Mean Absolute Error loss, which is less sensitive to outliers than MSE
+type L1Loss struct {
+	elementwise
+}
+
+// NewL1Loss creates a new mean absolute error loss function
+func NewL1Loss() *L1Loss {
+	return &L1Loss{}
+}
+
+// Forward computes L1 loss: mean(|predictions - targets|)
+func (l *L1Loss) Forward(predictions, targets *tensor.Dense) float64 {
+	return l.forward("L1Loss", predictions, targets, func(_ int, p, y float64) float64 {
+		return math.Abs(p - y)
+	})
+}
+
+// Backward computes gradient: sign(predictions - targets) / total_elements
+func (l *L1Loss) Backward() *tensor.Dense {
+	return l.backward(func(_ int, p, y float64) float64 {
+		switch {
+		case p > y:
+			return 1
+		case p < y:
+			return -1
+		}
+		return 0
+	})
+}
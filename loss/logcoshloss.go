@@ -0,0 +1,33 @@
+package loss
+
+import (
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// LogCoshLoss implements mean(log(cosh(predictions - targets))), which behaves like
+// MSE for small errors and like L1 for large ones while staying twice differentiable
+type LogCoshLoss struct {
+	elementwise
+}
+
+// NewLogCoshLoss creates a new log-cosh loss function
+func NewLogCoshLoss() *LogCoshLoss {
+	return &LogCoshLoss{}
+}
+
+// Forward computes mean(log(cosh(d))) as |d| + log1p(exp(-2|d|)) - log(2) to avoid overflow
+func (l *LogCoshLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	return l.forward("LogCoshLoss", predictions, targets, func(_ int, p, y float64) float64 {
+		d := math.Abs(p - y)
+		return d + math.Log1p(math.Exp(-2*d)) - math.Ln2
+	})
+}
+
+// Backward computes gradient: tanh(predictions - targets) / total_elements
+func (l *LogCoshLoss) Backward() *tensor.Dense {
+	return l.backward(func(_ int, p, y float64) float64 {
+		return math.Tanh(p - y)
+	})
+}
@@ -0,0 +1,48 @@
+package loss
+
+import (
+	"gorgonia.org/tensor"
+)
+
+// NLLLoss implements the negative log-likelihood loss on log-probabilities,
+// e.g. the output of a LogSoftmax layer, with one-hot encoded targets.
+// On (N, T, C) input every sample's loss is the sum over its T rows, so the mean divides by N.
+type NLLLoss struct {
+	Options
+	Predictions *tensor.Dense // cached log-probabilities for gradient computation
+	Targets     *tensor.Dense // cached targets (one-hot encoded)
+}
+
+// NewNLLLoss creates a new negative log-likelihood loss function
+func NewNLLLoss() *NLLLoss {
+	return &NLLLoss{}
+}
+
+// Forward computes NLL loss: -mean(sum(target * log_probability))
+func (l *NLLLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	l.Predictions = predictions
+	l.Targets = targets
+
+	predData := predictions.Data().([]float64)
+	targetData := targets.Data().([]float64)
+
+	shape := predictions.Shape()
+	cols := shape[len(shape)-1]
+	values := make([]float64, len(predData)/cols)
+	for i, lp := range predData {
+		values[i/cols] -= targetData[i] * lp
+	}
+	return l.reduceSummed("NLLLoss", values, shape[0])
+}
+
+// Backward computes gradient: -targets / batch_size
+func (l *NLLLoss) Backward() *tensor.Dense {
+	targetData := l.Targets.Data().([]float64)
+
+	gradData := make([]float64, len(targetData))
+	for i, y := range targetData {
+		gradData[i] = -y
+	}
+	l.scaleGrad(gradData)
+	return tensor.New(tensor.WithShape(l.Predictions.Shape()...), tensor.WithBacking(gradData))
+}
@@ -0,0 +1,48 @@
+package loss
+
+import (
+	"math"
+
+	"gorgonia.org/tensor"
+)
+
+// PoissonNLLLoss is the negative log-likelihood of count targets under a Poisson distribution
+// whose rate is predicted, by default as a log-rate so the model output needs no constraint
+type PoissonNLLLoss struct {
+	elementwise
+	LogInput bool    // whether predictions are log-rates rather than rates
+	Full     bool    // whether to add the Stirling approximation of log(target!) for targets above 1
+	Eps      float64 // added to rates before taking the log when LogInput is false
+}
+
+// NewPoissonNLLLoss creates a new Poisson negative log-likelihood loss on log-rates
+func NewPoissonNLLLoss() *PoissonNLLLoss {
+	return &PoissonNLLLoss{LogInput: true, Eps: 1e-8}
+}
+
+// Forward computes mean(exp(x) - target * x) on log-rates or mean(x - target * log(x + eps)) on rates
+func (l *PoissonNLLLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	return l.forward("PoissonNLLLoss", predictions, targets, func(_ int, x, y float64) float64 {
+		var value float64
+		if l.LogInput {
+			value = math.Exp(x) - y*x
+		} else {
+			value = x - y*math.Log(x+l.Eps)
+		}
+		if l.Full && y > 1 {
+			value += y*math.Log(y) - y + 0.5*math.Log(2*math.Pi*y)
+		}
+		return value
+	})
+}
+
+// Backward computes gradient: (exp(x) - target) / total_elements on log-rates
+// or (1 - target / (x + eps)) / total_elements on rates
+func (l *PoissonNLLLoss) Backward() *tensor.Dense {
+	return l.backward(func(_ int, x, y float64) float64 {
+		if l.LogInput {
+			return math.Exp(x) - y
+		}
+		return 1 - y/(x+l.Eps)
+	})
+}
@@ -0,0 +1,74 @@
+package loss
+
+import (
+	"fmt"
+
+	"gorgonia.org/tensor"
+)
+
+// QuantileLoss implements the pinball loss for quantile regression.
+// Predictions have one column per quantile, e.g. (N, 3) for quantiles 0.1, 0.5 and 0.9,
+// so a single model yields a median estimate together with a prediction interval.
+// Targets are (N) or (N, 1) and are compared against every column.
+type QuantileLoss struct {
+	elementwise
+	Quantiles []float64 // quantile level of every prediction column, each in (0, 1)
+}
+
+// NewQuantileLoss creates a new pinball loss for the given quantile levels
+func NewQuantileLoss(quantiles ...float64) *QuantileLoss {
+	if len(quantiles) == 0 {
+		panic("QuantileLoss: at least one quantile is required")
+	}
+	for _, q := range quantiles {
+		if q <= 0 || q >= 1 {
+			panic(fmt.Sprintf("QuantileLoss: quantile %v must be in (0, 1)", q))
+		}
+	}
+	return &QuantileLoss{Quantiles: append([]float64(nil), quantiles...)}
+}
+
+// Forward computes mean(max(q * (target - prediction), (q - 1) * (target - prediction)))
+// over all samples and quantiles
+func (l *QuantileLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	shape := predictions.Shape()
+	if len(shape) != 2 || shape[1] != len(l.Quantiles) {
+		panic(fmt.Sprintf("QuantileLoss: expected predictions (N, %d), got shape %v", len(l.Quantiles), shape))
+	}
+	targets = l.broadcastTargets(targets, shape[0])
+
+	return l.forward("QuantileLoss", predictions, targets, func(i int, p, y float64) float64 {
+		q := l.Quantiles[i%len(l.Quantiles)]
+		e := y - p
+		if e > 0 {
+			return q * e
+		}
+		return (q - 1) * e
+	})
+}
+
+// Backward computes gradient: -q where the target lies above the prediction and 1 - q otherwise, over total_elements
+func (l *QuantileLoss) Backward() *tensor.Dense {
+	return l.backward(func(i int, p, y float64) float64 {
+		q := l.Quantiles[i%len(l.Quantiles)]
+		if y > p {
+			return -q
+		}
+		return 1 - q
+	})
+}
+
+// broadcastTargets repeats every sample's target across the quantile columns
+func (l *QuantileLoss) broadcastTargets(targets *tensor.Dense, rows int) *tensor.Dense {
+	targetData := targets.Data().([]float64)
+	if len(targetData) != rows {
+		panic(fmt.Sprintf("QuantileLoss: expected %d targets, got shape %v", rows, targets.Shape()))
+	}
+	data := make([]float64, 0, rows*len(l.Quantiles))
+	for _, y := range targetData {
+		for range l.Quantiles {
+			data = append(data, y)
+		}
+	}
+	return tensor.New(tensor.WithShape(rows, len(l.Quantiles)), tensor.WithBacking(data))
+}
@@ -0,0 +1,166 @@
+package loss
+
+import (
+	"fmt"
+
+	"gorgonia.org/tensor"
+)
+
+// Reduction selects how element losses are combined into the value returned by Forward
+type Reduction int
+
+const (
+	ReductionMean Reduction = iota // weighted mean over the unmasked elements (default)
+	ReductionSum                   // weighted sum over the unmasked elements
+	ReductionNone                  // no reduction: the per-sample losses come from PerSample
+)
+
+// Options holds the reduction settings shared by every loss.
+// An element is one prediction for element-wise losses and one row of class scores
+// for the cross-entropy family, so Mask has one entry per element loss.
+type Options struct {
+	Reduction     Reduction     // how element losses are combined
+	SampleWeights *tensor.Dense // optional weight of every sample (N), nil for 1
+	Mask          *tensor.Dense // optional element mask; zero entries contribute neither loss nor gradient
+	perSample     *tensor.Dense // per-sample losses of the last forward pass
+	scale         []float64     // derivative of the reduced loss w.r.t. every element loss
+}
+
+// PerSample returns the loss of every sample from the last forward pass: the mean of its
+// unmasked element losses (their sum for the cross-entropy family), scaled by its sample weight.
+// With ReductionNone these are the result. Forward then returns their sum only so that Backward
+// gives every sample the gradient of its own loss; that value is neither the mean nor the sum
+// reduction and should not be compared with them.
+func (o *Options) PerSample() *tensor.Dense {
+	return o.perSample
+}
+
+// reduce combines the element losses of `samples` equally sized samples and records the
+// gradient scale of every element. norm gives the weight of each element in the mean's
+// denominator (nil for 1), which lets class-weighted losses average by total class weight.
+func (o *Options) reduce(name string, values, norm []float64, samples int) float64 {
+	return o.reduceWith(name, values, norm, samples, false)
+}
+
+// reduceSummed is reduce for losses whose sample loss is the sum of its element losses rather than
+// their mean, like the cross-entropy over the T rows of a (N, T, C) sequence: the mean then divides
+// by the weighted number of samples with an unmasked element instead of by the number of elements
+func (o *Options) reduceSummed(name string, values []float64, samples int) float64 {
+	return o.reduceWith(name, values, nil, samples, true)
+}
+
+// reduceWith implements reduce and reduceSummed
+func (o *Options) reduceWith(name string, values, norm []float64, samples int, summed bool) float64 {
+	if samples <= 0 || len(values)%samples != 0 {
+		panic(fmt.Sprintf("%s: %d element losses cannot be split into %d samples", name, len(values), samples))
+	}
+	perSample := len(values) / samples
+
+	weights := make([]float64, len(values))
+	for i := range weights {
+		weights[i] = 1
+	}
+	if o.Mask != nil {
+		maskData := o.Mask.Data().([]float64)
+		if len(maskData) != len(values) {
+			panic(fmt.Sprintf("%s: mask of shape %v does not match %d element losses", name, o.Mask.Shape(), len(values)))
+		}
+		for i, m := range maskData {
+			if m == 0 {
+				weights[i] = 0
+			}
+		}
+	}
+	sampleWeights := make([]float64, samples)
+	for r := range sampleWeights {
+		sampleWeights[r] = 1
+	}
+	if o.SampleWeights != nil {
+		weightData := o.SampleWeights.Data().([]float64)
+		if len(weightData) != samples {
+			panic(fmt.Sprintf("%s: sample weights of shape %v do not match %d samples", name, o.SampleWeights.Shape(), samples))
+		}
+		copy(sampleWeights, weightData)
+	}
+
+	// counts holds the denominator of every sample's own mean
+	perSampleData := make([]float64, samples)
+	counts := make([]float64, samples)
+	total, denominator := 0.0, 0.0
+	for i, v := range values {
+		if weights[i] == 0 {
+			continue
+		}
+		r := i / perSample
+		n := 1.0
+		if norm != nil {
+			n = norm[i]
+		}
+		perSampleData[r] += v
+		counts[r] += n
+		total += sampleWeights[r] * v
+		denominator += sampleWeights[r] * n
+	}
+	if summed {
+		// every sample with an unmasked element counts once, however many elements it has
+		denominator = 0
+		for r := range counts {
+			if counts[r] > 0 {
+				counts[r] = 1
+				denominator += sampleWeights[r]
+			}
+		}
+	}
+	for r := range perSampleData {
+		if counts[r] > 0 {
+			perSampleData[r] *= sampleWeights[r] / counts[r]
+		}
+	}
+	o.perSample = tensor.New(tensor.WithShape(samples), tensor.WithBacking(perSampleData))
+
+	o.scale = make([]float64, len(values))
+	for i := range values {
+		if weights[i] == 0 {
+			continue
+		}
+		r := i / perSample
+		switch o.Reduction {
+		case ReductionMean:
+			if denominator != 0 {
+				o.scale[i] = sampleWeights[r] / denominator
+			}
+		case ReductionSum:
+			o.scale[i] = sampleWeights[r]
+		case ReductionNone:
+			if counts[r] != 0 {
+				o.scale[i] = sampleWeights[r] / counts[r]
+			}
+		}
+	}
+
+	switch o.Reduction {
+	case ReductionMean:
+		if denominator == 0 {
+			return 0
+		}
+		return total / denominator
+	case ReductionSum:
+		return total
+	case ReductionNone:
+		sum := 0.0
+		for _, v := range perSampleData {
+			sum += v
+		}
+		return sum
+	}
+	panic(fmt.Sprintf("%s: unknown reduction %d", name, o.Reduction))
+}
+
+// scaleGrad multiplies raw gradients, laid out like the predictions, by the scale of the
+// element loss each prediction belongs to
+func (o *Options) scaleGrad(grad []float64) {
+	perElement := len(grad) / len(o.scale)
+	for i := range grad {
+		grad[i] *= o.scale[i/perElement]
+	}
+}
@@ -0,0 +1,114 @@
+package loss_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/loss"
+)
+
+// TestReductionNone checks that with ReductionNone, Forward returns the sum of PerSample and
+// Backward gives every sample the gradient of its own weighted mean, computed sample by sample
+func TestReductionNone(t *testing.T) {
+	pred := []float64{1, 2, 0.5, -1, 3, 0, 2, 2}
+	target := []float64{0, 1, 1, 1, 2, -1, 0, 2}
+	weights := []float64{1, 2, 0.5, 3}
+	mask := []float64{1, 1, 0, 1, 1, 0, 1, 1}
+	const samples, cols = 4, 2
+
+	l := loss.NewMSELoss()
+	l.Reduction = loss.ReductionNone
+	l.SampleWeights = dense(weights, samples)
+	l.Mask = dense(mask, samples, cols)
+	got := l.Forward(dense(pred, samples, cols), dense(target, samples, cols))
+	grad := l.Backward().Data().([]float64)
+	perSample := l.PerSample().Data().([]float64)
+
+	sum := 0.0
+	for r := 0; r < samples; r++ {
+		// Loss and gradient of sample r on its own: weight * mean of its unmasked squared errors
+		count, value := 0.0, 0.0
+		for j := r * cols; j < (r+1)*cols; j++ {
+			if mask[j] != 0 {
+				d := pred[j] - target[j]
+				value += d * d
+				count++
+			}
+		}
+		wantSample := weights[r] * value / count
+		if math.Abs(perSample[r]-wantSample) > 1e-12 {
+			t.Errorf("PerSample()[%d] = %v, want %v", r, perSample[r], wantSample)
+		}
+		sum += perSample[r]
+
+		for j := r * cols; j < (r+1)*cols; j++ {
+			wantGrad := 0.0
+			if mask[j] != 0 {
+				wantGrad = weights[r] * 2 * (pred[j] - target[j]) / count
+			}
+			if math.Abs(grad[j]-wantGrad) > 1e-12 {
+				t.Errorf("gradient[%d] = %v, want %v", j, grad[j], wantGrad)
+			}
+		}
+	}
+	if math.Abs(got-sum) > 1e-12 {
+		t.Errorf("Forward returned %v, want the sum of PerSample %v", got, sum)
+	}
+}
+
+// TestSequenceCrossEntropyNormalization checks that CrossEntropyLoss and NLLLoss sum the rows of
+// each (T, C) sample of a (N, T, C) batch and divide by N, and that a sample whose rows are all
+// masked drops out of that count
+func TestSequenceCrossEntropyNormalization(t *testing.T) {
+	probs := []float64{0.7, 0.3, 0.4, 0.6, 0.5, 0.5, 0.2, 0.8, 0.9, 0.1, 0.25, 0.75}
+	targets := []float64{1, 0, 0, 1, 1, 0, 0, 1, 1, 0, 0, 1}
+	logProbs := make([]float64, len(probs))
+	for i, p := range probs {
+		logProbs[i] = math.Log(p)
+	}
+	// every row of the second sample is masked
+	mask := dense([]float64{1, 1, 1, 0, 0, 0}, 2, 3)
+
+	crossEntropy, maskedCrossEntropy := loss.NewCrossEntropyLoss(), loss.NewCrossEntropyLoss()
+	maskedCrossEntropy.Mask = mask
+	nll, maskedNLL := loss.NewNLLLoss(), loss.NewNLLLoss()
+	maskedNLL.Mask = mask
+
+	for _, c := range []struct {
+		name    string
+		l       loss.Loss
+		pred    []float64
+		samples int  // number of unmasked samples
+		onProbs bool // the loss takes probabilities, so its gradient is divided by them
+	}{
+		{"CrossEntropy", crossEntropy, probs, 2, true},
+		{"CrossEntropy masked", maskedCrossEntropy, probs, 1, true},
+		{"NLL", nll, logProbs, 2, false},
+		{"NLL masked", maskedNLL, logProbs, 1, false},
+	} {
+		got := c.l.Forward(dense(append([]float64(nil), c.pred...), 2, 3, 2), dense(targets, 2, 3, 2))
+		grad := c.l.Backward().Data().([]float64)
+
+		// -sum(target * log p) over the rows of the unmasked samples, divided by their number
+		want := 0.0
+		for i := 0; i < c.samples*6; i++ {
+			want -= targets[i] * math.Log(probs[i])
+		}
+		want /= float64(c.samples)
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s: loss %v, want %v", c.name, got, want)
+		}
+		for i, g := range grad {
+			wantGrad := 0.0
+			if i < c.samples*6 {
+				wantGrad = -targets[i] / float64(c.samples)
+				if c.onProbs {
+					wantGrad /= probs[i]
+				}
+			}
+			if math.Abs(g-wantGrad) > 1e-6 {
+				t.Errorf("%s: gradient[%d] = %v, want %v", c.name, i, g, wantGrad)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package loss
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"gorgonia.org/tensor"
+)
+
+// TripletMarginLoss pulls an anchor towards a positive and pushes it away from a negative embedding.
+// Predictions of shape (3N, D) stack N anchors, then N positives, then N negatives, so a single
+// forward pass of a shared network over the concatenated batch produces all three. Targets are unused.
+type TripletMarginLoss struct {
+	graphLoss
+	Margin float64 // required gap between the positive and negative distances
+}
+
+// NewTripletMarginLoss creates a new triplet loss with the given margin
+func NewTripletMarginLoss(margin float64) *TripletMarginLoss {
+	return &TripletMarginLoss{Margin: margin}
+}
+
+// Forward computes mean(max(||a - p|| - ||a - n|| + margin, 0))
+func (l *TripletMarginLoss) Forward(predictions, targets *tensor.Dense) float64 {
+	n := splitBlocks("TripletMarginLoss", predictions, 3)
+	return l.forward("TripletMarginLoss", predictions, n, func(t *autograd.Tape, pred *autograd.Variable) *autograd.Variable {
+		anchor := t.Narrow(pred, 0, 0, n)
+		positive := t.Narrow(pred, 0, n, n)
+		negative := t.Narrow(pred, 0, 2*n, n)
+		gap := t.Sub(pairwiseDistance(t, anchor, positive), pairwiseDistance(t, anchor, negative))
+		return t.ReLU(t.AddScalar(gap, l.Margin))
+	})
+}
+
+// Backward computes gradient w.r.t. the stacked embeddings
+func (l *TripletMarginLoss) Backward() *tensor.Dense {
+	return l.backward()
+}
+
+// pairwiseDistance returns the L2 distance between matching rows, with PyTorch's eps of 1e-6
+// added to the difference so the gradient stays finite for identical rows
+func pairwiseDistance(t *autograd.Tape, a, b *autograd.Variable) *autograd.Variable {
+	return t.Sqrt(rowSums(t, t.Square(t.AddScalar(t.Sub(a, b), 1e-6))))
+}
+
+// splitBlocks checks that 2D predictions hold the given number of equally sized row blocks
+// and returns the block size
+func splitBlocks(name string, predictions *tensor.Dense, blocks int) int {
+	shape := predictions.Shape()
+	if len(shape) != 2 || shape[0] == 0 || shape[0]%blocks != 0 {
+		panic(fmt.Sprintf("%s: expected predictions (%d*N, D), got shape %v", name, blocks, shape))
+	}
+	return shape[0] / blocks
+}
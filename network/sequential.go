@@ -1,6 +1,7 @@
 package network
 
 import (
+	"github.com/VigyatGoel/gotorch/autograd"
 	"github.com/VigyatGoel/gotorch/layer"
 	"github.com/VigyatGoel/gotorch/optimizer"
 	"github.com/VigyatGoel/gotorch/persistence"
@@ -26,12 +27,19 @@ func NewSequential(layers ...layer.Layer) *Sequential {
 	}
 }
 
+// SetOptimizer attaches opt to the model and registers all model parameters with it
 func (s *Sequential) SetOptimizer(opt optimizer.Optimizer) {
 	s.Optimizer = opt
+	if opt != nil {
+		opt.SetParameters(s.Parameters())
+	}
 }
 
 func (s *Sequential) Add(layer layer.Layer) {
 	s.Layers = append(s.Layers, layer)
+	if s.Optimizer != nil {
+		s.Optimizer.SetParameters(s.Parameters())
+	}
 }
 
 func (s *Sequential) Forward(input *tensor.Dense) *tensor.Dense {
@@ -42,48 +50,49 @@ func (s *Sequential) Forward(input *tensor.Dense) *tensor.Dense {
 	return output
 }
 
+// Backward back-propagates gradOutput through all layers, accumulating gradients
+// into their parameters. It does not update the parameters; call Optimizer.Step for that.
 func (s *Sequential) Backward(gradOutput *tensor.Dense) *tensor.Dense {
 	for i := len(s.Layers) - 1; i >= 0; i-- {
 		gradOutput = s.Layers[i].Backward(gradOutput)
 	}
+	return gradOutput
+}
 
-	if s.Optimizer != nil {
-		for _, l := range s.Layers {
-			weights := l.GetWeights()
-			gradients := l.GetGradients()
-			if weights != nil && gradients != nil {
-				updatedWeights := s.Optimizer.Step(weights, gradients)
-				l.UpdateWeights(updatedWeights)
-			}
-
-			biases := l.GetBiases()
-			biasGradients := l.GetBiasGradients()
-			if biases != nil && biasGradients != nil {
-				updatedBiases := s.Optimizer.StepBias(biases, biasGradients)
-				l.UpdateBiases(updatedBiases)
-			}
-		}
+// ZeroGrad clears the gradients of all model parameters
+func (s *Sequential) ZeroGrad() {
+	for _, p := range s.Parameters() {
+		p.ZeroGrad()
 	}
+}
 
-	return gradOutput
+// Parameters returns the parameters of all layers in order
+func (s *Sequential) Parameters() []*autograd.Parameter {
+	var params []*autograd.Parameter
+	for _, l := range s.Layers {
+		params = append(params, l.Parameters()...)
+	}
+	return params
 }
 
 func (s *Sequential) Predict(input *tensor.Dense) *tensor.Dense {
 	return s.Forward(input)
 }
 
+// Train switches layers with mode-dependent behaviour (Dropout, BatchNorm) to training mode
 func (s *Sequential) Train() {
-	for _, l := range s.Layers {
-		if dropout, ok := l.(*layer.Dropout); ok {
-			dropout.SetTraining(true)
-		}
-	}
+	s.setTraining(true)
 }
 
+// Eval switches layers with mode-dependent behaviour (Dropout, BatchNorm) to inference mode
 func (s *Sequential) Eval() {
+	s.setTraining(false)
+}
+
+func (s *Sequential) setTraining(training bool) {
 	for _, l := range s.Layers {
-		if dropout, ok := l.(*layer.Dropout); ok {
-			dropout.SetTraining(false)
+		if trainable, ok := l.(interface{ SetTraining(bool) }); ok {
+			trainable.SetTraining(training)
 		}
 	}
 }
@@ -116,8 +125,10 @@ func Load(filePath string) (*Sequential, error) {
 	}
 
 	model := &Sequential{
-		Layers:    modelData.Layers,
-		Optimizer: modelData.Optimizer,
+		Layers: modelData.Layers,
+	}
+	if modelData.Optimizer != nil {
+		model.SetOptimizer(modelData.Optimizer)
 	}
 
 	return model, nil
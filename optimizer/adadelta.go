@@ -0,0 +1,70 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// Adadelta implements the Adadelta optimizer, which replaces a global step size by the
+// ratio of running averages of past updates and past gradients.
+// It follows the standard PyTorch formulation:
+//
+//	square_avg = rho * square_avg + (1 - rho) * gradient^2
+//	delta = sqrt(acc_delta + epsilon) / sqrt(square_avg + epsilon) * gradient
+//	acc_delta = rho * acc_delta + (1 - rho) * delta^2
+//	parameter = parameter - learning_rate * delta
+type Adadelta struct {
+	LR      float64
+	Rho     float64
+	Epsilon float64
+	square  map[*autograd.Parameter][]float64
+	delta   map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewAdadelta creates a new Adadelta optimizer with the specified parameters.
+// Common values are lr 1.0, rho 0.9 and epsilon 1e-6.
+func NewAdadelta(lr, rho, epsilon float64) *Adadelta {
+	return &Adadelta{
+		LR:      lr,
+		Rho:     rho,
+		Epsilon: epsilon,
+		square:  make(map[*autograd.Parameter][]float64),
+		delta:   make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultAdadelta creates a new Adadelta optimizer with the specified learning rate
+// and default parameters (rho=0.9, epsilon=1e-6).
+func DefaultAdadelta(lr float64) *Adadelta {
+	return NewAdadelta(lr, 0.9, 1e-6)
+}
+
+func (a *Adadelta) Step() {
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		squareData := state(a.square, p)
+		deltaData := state(a.delta, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			squareData[i] = a.Rho*squareData[i] + (1-a.Rho)*g*g
+			delta := math.Sqrt(deltaData[i]+a.Epsilon) / math.Sqrt(squareData[i]+a.Epsilon) * g
+			deltaData[i] = a.Rho*deltaData[i] + (1-a.Rho)*delta*delta
+			paramData[i] -= a.LR * delta
+		}
+	}
+}
+
+func (a *Adadelta) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *Adadelta) SetLearningRate(lr float64) {
+	a.LR = lr
+}
@@ -0,0 +1,68 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// Adagrad implements the Adagrad optimizer, which scales every coordinate by the inverse
+// root of its accumulated squared gradients so rarely updated parameters take larger steps.
+// It follows the standard PyTorch formulation:
+//
+//	lr_t = learning_rate / (1 + (t - 1) * lr_decay)
+//	sum = sum + gradient^2
+//	parameter = parameter - lr_t * gradient / (sqrt(sum) + epsilon)
+type Adagrad struct {
+	LR      float64
+	LRDecay float64
+	Epsilon float64
+	T       int
+	sum     map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewAdagrad creates a new Adagrad optimizer with the specified parameters.
+// Common values are lr 0.01, lrDecay 0 and epsilon 1e-10.
+func NewAdagrad(lr, lrDecay, epsilon float64) *Adagrad {
+	return &Adagrad{
+		LR:      lr,
+		LRDecay: lrDecay,
+		Epsilon: epsilon,
+		sum:     make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultAdagrad creates a new Adagrad optimizer with the specified learning rate
+// and default parameters (lr_decay=0, epsilon=1e-10).
+func DefaultAdagrad(lr float64) *Adagrad {
+	return NewAdagrad(lr, 0, 1e-10)
+}
+
+func (a *Adagrad) Step() {
+	a.T++
+	lr := a.LR / (1 + float64(a.T-1)*a.LRDecay)
+
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		sumData := state(a.sum, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			sumData[i] += g * g
+			paramData[i] -= lr * g / (math.Sqrt(sumData[i]) + a.Epsilon)
+		}
+	}
+}
+
+func (a *Adagrad) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *Adagrad) SetLearningRate(lr float64) {
+	a.LR = lr
+}
@@ -0,0 +1,82 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// AdamW implements Adam with decoupled weight decay (Loshchilov & Hutter).
+// Instead of adding an L2 term to the gradient, where the adaptive scaling would weaken it,
+// the parameters are shrunk directly before the Adam update:
+//
+//	parameter = parameter - learning_rate * weight_decay * parameter
+//	parameter = parameter - learning_rate * m_hat / (sqrt(v_hat) + epsilon)
+//
+// with m_hat and v_hat the bias-corrected moment estimates of Adam.
+type AdamW struct {
+	LR          float64
+	Beta1       float64
+	Beta2       float64
+	Epsilon     float64
+	WeightDecay float64
+	T           int
+	m           map[*autograd.Parameter][]float64
+	v           map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewAdamW creates a new AdamW optimizer with the specified parameters.
+// Common values are lr 0.001, beta1 0.9, beta2 0.999, epsilon 1e-8 and weightDecay 0.01.
+func NewAdamW(lr, beta1, beta2, epsilon, weightDecay float64) *AdamW {
+	return &AdamW{
+		LR:          lr,
+		Beta1:       beta1,
+		Beta2:       beta2,
+		Epsilon:     epsilon,
+		WeightDecay: weightDecay,
+		m:           make(map[*autograd.Parameter][]float64),
+		v:           make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultAdamW creates a new AdamW optimizer with the specified learning rate
+// and default parameters (beta1=0.9, beta2=0.999, epsilon=1e-8, weight_decay=0.01).
+func DefaultAdamW(lr float64) *AdamW {
+	return NewAdamW(lr, 0.9, 0.999, 1e-8, 0.01)
+}
+
+func (a *AdamW) Step() {
+	a.T++
+	beta1_t := math.Pow(a.Beta1, float64(a.T))
+	beta2_t := math.Pow(a.Beta2, float64(a.T))
+
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		mData := state(a.m, p)
+		vData := state(a.v, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			// Decoupled weight decay acts on the parameter, not the gradient
+			paramData[i] -= a.LR * a.WeightDecay * paramData[i]
+			mData[i] = a.Beta1*mData[i] + (1-a.Beta1)*g
+			vData[i] = a.Beta2*vData[i] + (1-a.Beta2)*g*g
+			mHat := mData[i] / (1 - beta1_t)
+			vHat := vData[i] / (1 - beta2_t)
+			paramData[i] -= a.LR * mHat / (math.Sqrt(vHat) + a.Epsilon)
+		}
+	}
+}
+
+func (a *AdamW) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *AdamW) SetLearningRate(lr float64) {
+	a.LR = lr
+}
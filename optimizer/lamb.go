@@ -0,0 +1,94 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// LAMB implements layer-wise adaptive moments for large-batch training (You et al.).
+// Each parameter tensor takes an Adam step with L2 weight decay, rescaled by a trust
+// ratio so its size is proportional to the norm of the parameter itself:
+//
+//	update = m_hat / (sqrt(v_hat) + epsilon) + weight_decay * parameter
+//	trust_ratio = ||parameter|| / ||update||   (1 if either norm is zero)
+//	parameter = parameter - learning_rate * trust_ratio * update
+//
+// with m_hat and v_hat the bias-corrected moment estimates of Adam.
+type LAMB struct {
+	LR          float64
+	Beta1       float64
+	Beta2       float64
+	Epsilon     float64
+	WeightDecay float64
+	T           int
+	m           map[*autograd.Parameter][]float64
+	v           map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewLAMB creates a new LAMB optimizer with the specified parameters.
+// Common values are lr 0.001, beta1 0.9, beta2 0.999, epsilon 1e-6 and weightDecay 0.01.
+func NewLAMB(lr, beta1, beta2, epsilon, weightDecay float64) *LAMB {
+	return &LAMB{
+		LR:          lr,
+		Beta1:       beta1,
+		Beta2:       beta2,
+		Epsilon:     epsilon,
+		WeightDecay: weightDecay,
+		m:           make(map[*autograd.Parameter][]float64),
+		v:           make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultLAMB creates a new LAMB optimizer with the specified learning rate
+// and default parameters (beta1=0.9, beta2=0.999, epsilon=1e-6, weight_decay=0.01).
+func DefaultLAMB(lr float64) *LAMB {
+	return NewLAMB(lr, 0.9, 0.999, 1e-6, 0.01)
+}
+
+func (a *LAMB) Step() {
+	a.T++
+	beta1_t := math.Pow(a.Beta1, float64(a.T))
+	beta2_t := math.Pow(a.Beta2, float64(a.T))
+
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		mData := state(a.m, p)
+		vData := state(a.v, p)
+		gradData := p.Grad.Data().([]float64)
+		update := make([]float64, len(paramData))
+		paramNorm, updateNorm := 0.0, 0.0
+		for i := range paramData {
+			g := gradData[i]
+			mData[i] = a.Beta1*mData[i] + (1-a.Beta1)*g
+			vData[i] = a.Beta2*vData[i] + (1-a.Beta2)*g*g
+			mHat := mData[i] / (1 - beta1_t)
+			vHat := vData[i] / (1 - beta2_t)
+			update[i] = mHat/(math.Sqrt(vHat)+a.Epsilon) + a.WeightDecay*paramData[i]
+			paramNorm += paramData[i] * paramData[i]
+			updateNorm += update[i] * update[i]
+		}
+
+		// The trust ratio makes the step size relative to the scale of each layer
+		trustRatio := 1.0
+		if paramNorm > 0 && updateNorm > 0 {
+			trustRatio = math.Sqrt(paramNorm) / math.Sqrt(updateNorm)
+		}
+		for i := range paramData {
+			paramData[i] -= a.LR * trustRatio * update[i]
+		}
+	}
+}
+
+func (a *LAMB) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *LAMB) SetLearningRate(lr float64) {
+	a.LR = lr
+}
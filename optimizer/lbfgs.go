@@ -0,0 +1,433 @@
+package optimizer
+
+import (
+	"fmt"
+	"math"
+)
+
+// LineSearchStrongWolfe selects a line search satisfying the strong Wolfe conditions in LBFGS
+const LineSearchStrongWolfe = "strong_wolfe"
+
+// Closure re-evaluates the model for LBFGS: it must clear the gradients, run the forward
+// and backward passes on the full data and return the loss
+type Closure func() float64
+
+// LBFGS implements the limited-memory BFGS quasi-Newton method, ported from PyTorch.
+// It approximates the inverse Hessian from the last HistorySize parameter and gradient
+// differences, which makes it converge in far fewer steps than first-order methods on
+// small, smooth, full-batch problems.
+//
+// Each Step runs up to MaxIter iterations, so it needs to evaluate the loss several times;
+// it does so through Closure. Every registered parameter is treated as one flat vector.
+//
+// Typical usage with a Sequential model on the full training set:
+//
+//	opt := optimizer.DefaultLBFGS(1)
+//	model.SetOptimizer(opt)
+//	x, y := dataLoader.GetAll()
+//	opt.Closure = func() float64 {
+//		opt.ZeroGrad()
+//		lossVal := criterion.Forward(model.Forward(x), y)
+//		model.Backward(criterion.Backward())
+//		return lossVal
+//	}
+//	for epoch := 0; epoch < epochs; epoch++ {
+//		opt.Step()
+//	}
+type LBFGS struct {
+	LR              float64
+	MaxIter         int     // iterations per Step
+	MaxEval         int     // closure evaluations per Step
+	ToleranceGrad   float64 // stop once the largest gradient entry is at most this
+	ToleranceChange float64 // stop once the step or the loss change is smaller than this
+	HistorySize     int     // number of correction pairs kept
+	LineSearch      string  // "" for a fixed step of LR, or LineSearchStrongWolfe
+	Closure         Closure // evaluates loss and gradients, used by Step
+	Loss            float64 // loss at the start of the last Step
+
+	// state carried across steps
+	nIter        int
+	direction    []float64
+	stepSize     float64
+	oldDirs      [][]float64
+	oldSteps     [][]float64
+	ro           []float64
+	hDiag        float64
+	prevFlatGrad []float64
+	parameterList
+}
+
+// NewLBFGS creates a new LBFGS optimizer with the specified learning rate, iterations per step,
+// history size and line search, with PyTorch's default tolerances and MaxEval of 1.25 * maxIter.
+// maxIter and historySize must be positive.
+func NewLBFGS(lr float64, maxIter, historySize int, lineSearch string) *LBFGS {
+	o := &LBFGS{
+		LR:              lr,
+		MaxIter:         maxIter,
+		MaxEval:         maxIter * 5 / 4,
+		ToleranceGrad:   1e-7,
+		ToleranceChange: 1e-9,
+		HistorySize:     historySize,
+		LineSearch:      lineSearch,
+	}
+	o.validate()
+	return o
+}
+
+// DefaultLBFGS creates a new LBFGS optimizer with the specified learning rate
+// and default parameters (max_iter=20, history_size=100, strong Wolfe line search).
+func DefaultLBFGS(lr float64) *LBFGS {
+	return NewLBFGS(lr, 20, 100, LineSearchStrongWolfe)
+}
+
+// Step minimizes the loss computed by Closure for up to MaxIter iterations
+func (o *LBFGS) Step() {
+	o.StepClosure(o.Closure)
+}
+
+// StepClosure runs Step with the given closure and returns the loss before the step
+func (o *LBFGS) StepClosure(closure Closure) float64 {
+	if closure == nil {
+		panic("LBFGS: Step needs a closure that re-evaluates the loss and gradients")
+	}
+	o.validate()
+
+	loss := closure()
+	o.Loss = loss
+	currentEvals := 1
+	flatGrad := o.gatherFlatGrad()
+	if maxAbs(flatGrad) <= o.ToleranceGrad {
+		return o.Loss
+	}
+
+	d, t := o.direction, o.stepSize
+	for n := 1; n <= o.MaxIter; n++ {
+		o.nIter++
+
+		// Compute the descent direction with the two-loop recursion
+		if o.nIter == 1 {
+			d = scaled(flatGrad, -1)
+			o.oldDirs, o.oldSteps, o.ro = nil, nil, nil
+			o.hDiag = 1
+		} else {
+			y := make([]float64, len(flatGrad))
+			for i := range y {
+				y[i] = flatGrad[i] - o.prevFlatGrad[i]
+			}
+			s := scaled(d, t)
+			ys := dot(y, s)
+			if ys > 1e-10 {
+				if len(o.oldDirs) == o.HistorySize {
+					o.oldDirs, o.oldSteps, o.ro = o.oldDirs[1:], o.oldSteps[1:], o.ro[1:]
+				}
+				o.oldDirs = append(o.oldDirs, y)
+				o.oldSteps = append(o.oldSteps, s)
+				o.ro = append(o.ro, 1/ys)
+				o.hDiag = ys / dot(y, y)
+			}
+
+			al := make([]float64, len(o.oldDirs))
+			q := scaled(flatGrad, -1)
+			for i := len(o.oldDirs) - 1; i >= 0; i-- {
+				al[i] = dot(o.oldSteps[i], q) * o.ro[i]
+				axpy(-al[i], o.oldDirs[i], q)
+			}
+			d = scaled(q, o.hDiag)
+			for i := range o.oldDirs {
+				be := dot(o.oldDirs[i], d) * o.ro[i]
+				axpy(al[i]-be, o.oldSteps[i], d)
+			}
+		}
+		o.prevFlatGrad = append(o.prevFlatGrad[:0], flatGrad...)
+		prevLoss := loss
+
+		// The first step is shrunk since there is no curvature information yet
+		if o.nIter == 1 {
+			t = math.Min(1, 1/sumAbs(flatGrad)) * o.LR
+		} else {
+			t = o.LR
+		}
+
+		gtd := dot(flatGrad, d)
+		if gtd > -o.ToleranceChange {
+			break
+		}
+
+		evals := 0
+		if o.LineSearch == LineSearchStrongWolfe {
+			x := o.cloneParams()
+			loss, flatGrad, t, evals = o.strongWolfe(closure, x, t, d, loss, flatGrad, gtd)
+			o.addToParams(t, d)
+		} else {
+			o.addToParams(t, d)
+			if n != o.MaxIter {
+				loss = closure()
+				flatGrad = o.gatherFlatGrad()
+				evals = 1
+			}
+		}
+		currentEvals += evals
+
+		if n == o.MaxIter || currentEvals >= o.MaxEval {
+			break
+		}
+		if maxAbs(flatGrad) <= o.ToleranceGrad {
+			break
+		}
+		if maxAbs(d)*math.Abs(t) <= o.ToleranceChange || math.Abs(loss-prevLoss) < o.ToleranceChange {
+			break
+		}
+	}
+
+	o.direction, o.stepSize = d, t
+	return o.Loss
+}
+
+func (o *LBFGS) GetLearningRate() float64 {
+	return o.LR
+}
+
+func (o *LBFGS) SetLearningRate(lr float64) {
+	o.LR = lr
+}
+
+// validate panics on settings Step cannot run with
+func (o *LBFGS) validate() {
+	if o.MaxIter <= 0 {
+		panic(fmt.Sprintf("LBFGS: max iterations %d must be positive", o.MaxIter))
+	}
+	if o.HistorySize <= 0 {
+		panic(fmt.Sprintf("LBFGS: history size %d must be positive", o.HistorySize))
+	}
+	if o.LineSearch != "" && o.LineSearch != LineSearchStrongWolfe {
+		panic(fmt.Sprintf("LBFGS: unknown line search %q", o.LineSearch))
+	}
+}
+
+// gatherFlatGrad concatenates the gradients of all parameters, using zeros for missing ones
+func (o *LBFGS) gatherFlatGrad() []float64 {
+	var flat []float64
+	for _, p := range o.params {
+		if p == nil || !p.RequiresGrad {
+			continue
+		}
+		if p.Grad == nil {
+			flat = append(flat, make([]float64, len(p.Data()))...)
+			continue
+		}
+		flat = append(flat, p.Grad.Data().([]float64)...)
+	}
+	return flat
+}
+
+// addToParams moves the parameters by step * direction
+func (o *LBFGS) addToParams(step float64, direction []float64) {
+	offset := 0
+	for _, p := range o.params {
+		if p == nil || !p.RequiresGrad {
+			continue
+		}
+		paramData := p.Data()
+		for i := range paramData {
+			paramData[i] += step * direction[offset+i]
+		}
+		offset += len(paramData)
+	}
+}
+
+// cloneParams copies the current values of all parameters into one flat vector
+func (o *LBFGS) cloneParams() []float64 {
+	var flat []float64
+	for _, p := range o.params {
+		if p == nil || !p.RequiresGrad {
+			continue
+		}
+		flat = append(flat, p.Data()...)
+	}
+	return flat
+}
+
+// setParams restores the parameters from a flat vector returned by cloneParams
+func (o *LBFGS) setParams(flat []float64) {
+	offset := 0
+	for _, p := range o.params {
+		if p == nil || !p.RequiresGrad {
+			continue
+		}
+		offset += copy(p.Data(), flat[offset:])
+	}
+}
+
+// directionalEvaluate returns the loss and gradient at x + step * direction, leaving the parameters at x
+func (o *LBFGS) directionalEvaluate(closure Closure, x []float64, step float64, direction []float64) (float64, []float64) {
+	o.addToParams(step, direction)
+	loss := closure()
+	flatGrad := o.gatherFlatGrad()
+	o.setParams(x)
+	return loss, flatGrad
+}
+
+// strongWolfe searches along d from x for a step t satisfying the strong Wolfe conditions,
+// first bracketing an acceptable step and then zooming into the bracket (Nocedal & Wright, Algorithm 3.5).
+// It returns the loss and gradient at the accepted step, the step and the number of closure evaluations.
+func (o *LBFGS) strongWolfe(closure Closure, x []float64, t float64, d []float64, f float64, g []float64, gtd float64) (float64, []float64, float64, int) {
+	const (
+		c1    = 1e-4
+		c2    = 0.9
+		maxLS = 25
+	)
+	dNorm := maxAbs(d)
+	fNew, gNew := o.directionalEvaluate(closure, x, t, d)
+	evals := 1
+	gtdNew := dot(gNew, d)
+
+	tPrev, fPrev, gPrev, gtdPrev := 0.0, f, g, gtd
+	var bracket, bracketF, bracketGtd [2]float64
+	var bracketG [2][]float64
+	done := false
+	lsIter := 0
+	for lsIter < maxLS {
+		if fNew > f+c1*t*gtd || (lsIter > 1 && fNew >= fPrev) {
+			bracket, bracketF, bracketG, bracketGtd = [2]float64{tPrev, t}, [2]float64{fPrev, fNew}, [2][]float64{gPrev, gNew}, [2]float64{gtdPrev, gtdNew}
+			break
+		}
+		if math.Abs(gtdNew) <= -c2*gtd {
+			bracket, bracketF, bracketG, bracketGtd = [2]float64{t, t}, [2]float64{fNew, fNew}, [2][]float64{gNew, gNew}, [2]float64{gtdNew, gtdNew}
+			done = true
+			break
+		}
+		if gtdNew >= 0 {
+			// The slope turned positive, so a minimizer lies between tPrev and t
+			bracket, bracketF, bracketG, bracketGtd = [2]float64{tPrev, t}, [2]float64{fPrev, fNew}, [2][]float64{gPrev, gNew}, [2]float64{gtdPrev, gtdNew}
+			break
+		}
+
+		// Extrapolate beyond t, interpolating within [t + 0.01 * (t - tPrev), 10 * t]
+		minStep := t + 0.01*(t-tPrev)
+		maxStep := t * 10
+		tNext := cubicInterpolate(tPrev, fPrev, gtdPrev, t, fNew, gtdNew, minStep, maxStep)
+		tPrev, fPrev, gPrev, gtdPrev = t, fNew, gNew, gtdNew
+		t = tNext
+		fNew, gNew = o.directionalEvaluate(closure, x, t, d)
+		evals++
+		gtdNew = dot(gNew, d)
+		lsIter++
+	}
+	if lsIter == maxLS {
+		bracket, bracketF, bracketG, bracketGtd = [2]float64{0, t}, [2]float64{f, fNew}, [2][]float64{g, gNew}, [2]float64{gtd, gtdNew}
+	}
+
+	// Zoom into the bracket until a step satisfies the conditions
+	insufficientProgress := false
+	low, high := 0, 1
+	if bracketF[0] > bracketF[1] {
+		low, high = 1, 0
+	}
+	for !done && lsIter < maxLS {
+		if math.Abs(bracket[1]-bracket[0])*dNorm < o.ToleranceChange {
+			break
+		}
+		lo, hi := math.Min(bracket[0], bracket[1]), math.Max(bracket[0], bracket[1])
+		t = cubicInterpolate(bracket[0], bracketF[0], bracketGtd[0], bracket[1], bracketF[1], bracketGtd[1], lo, hi)
+
+		// Keep t away from the bracket ends so the bracket keeps shrinking
+		eps := 0.1 * (hi - lo)
+		if math.Min(hi-t, t-lo) < eps {
+			if insufficientProgress || t >= hi || t <= lo {
+				if math.Abs(t-hi) < math.Abs(t-lo) {
+					t = hi - eps
+				} else {
+					t = lo + eps
+				}
+				insufficientProgress = false
+			} else {
+				insufficientProgress = true
+			}
+		} else {
+			insufficientProgress = false
+		}
+
+		fNew, gNew = o.directionalEvaluate(closure, x, t, d)
+		evals++
+		gtdNew = dot(gNew, d)
+		lsIter++
+
+		if fNew > f+c1*t*gtd || fNew >= bracketF[low] {
+			bracket[high], bracketF[high], bracketG[high], bracketGtd[high] = t, fNew, gNew, gtdNew
+			low, high = 0, 1
+			if bracketF[0] > bracketF[1] {
+				low, high = 1, 0
+			}
+			continue
+		}
+		if math.Abs(gtdNew) <= -c2*gtd {
+			done = true
+		} else if gtdNew*(bracket[high]-bracket[low]) >= 0 {
+			bracket[high], bracketF[high], bracketG[high], bracketGtd[high] = bracket[low], bracketF[low], bracketG[low], bracketGtd[low]
+		}
+		bracket[low], bracketF[low], bracketG[low], bracketGtd[low] = t, fNew, gNew, gtdNew
+	}
+
+	return bracketF[low], bracketG[low], bracket[low], evals
+}
+
+// cubicInterpolate returns the minimizer of the cubic through (x1, f1) and (x2, f2) with slopes g1 and g2,
+// clamped to [lo, hi], or the middle of the bounds when the cubic has no minimum
+func cubicInterpolate(x1, f1, g1, x2, f2, g2, lo, hi float64) float64 {
+	d1 := g1 + g2 - 3*(f1-f2)/(x1-x2)
+	d2Square := d1*d1 - g1*g2
+	if d2Square < 0 {
+		return (lo + hi) / 2
+	}
+	d2 := math.Sqrt(d2Square)
+	var minPos float64
+	if x1 <= x2 {
+		minPos = x2 - (x2-x1)*((g2+d2-d1)/(g2-g1+2*d2))
+	} else {
+		minPos = x1 - (x1-x2)*((g1+d2-d1)/(g1-g2+2*d2))
+	}
+	return math.Min(math.Max(minPos, lo), hi)
+}
+
+// dot returns the inner product of a and b
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i, v := range a {
+		sum += v * b[i]
+	}
+	return sum
+}
+
+// axpy adds alpha * x to y in place
+func axpy(alpha float64, x, y []float64) {
+	for i, v := range x {
+		y[i] += alpha * v
+	}
+}
+
+// scaled returns a new slice holding alpha * x
+func scaled(x []float64, alpha float64) []float64 {
+	out := make([]float64, len(x))
+	for i, v := range x {
+		out[i] = alpha * v
+	}
+	return out
+}
+
+// maxAbs returns the largest absolute entry of x
+func maxAbs(x []float64) float64 {
+	m := 0.0
+	for _, v := range x {
+		m = math.Max(m, math.Abs(v))
+	}
+	return m
+}
+
+// sumAbs returns the sum of absolute entries of x
+func sumAbs(x []float64) float64 {
+	sum := 0.0
+	for _, v := range x {
+		sum += math.Abs(v)
+	}
+	return sum
+}
@@ -0,0 +1,123 @@
+package optimizer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"github.com/VigyatGoel/gotorch/optimizer"
+	"gorgonia.org/tensor"
+)
+
+// quadratic sets up f(w) = 0.5 * w^T A w - b^T w for a fixed positive definite A, whose minimizer
+// is A^-1 b = (1, -2, 0.5), and returns the parameter, a closure evaluating f and its gradient,
+// and the minimizer
+func quadratic(o *optimizer.LBFGS) (*autograd.Parameter, optimizer.Closure, []float64) {
+	a := [3][3]float64{{4, 1, 0}, {1, 3, -1}, {0, -1, 2}}
+	minimizer := []float64{1, -2, 0.5}
+	var b [3]float64
+	for i := range a {
+		for j := range a[i] {
+			b[i] += a[i][j] * minimizer[j]
+		}
+	}
+
+	w := autograd.NewParameter("w", tensor.New(tensor.WithShape(3), tensor.WithBacking([]float64{5, 5, 5})))
+	o.SetParameters([]*autograd.Parameter{w})
+	closure := func() float64 {
+		o.ZeroGrad()
+		x := w.Data()
+		grad := make([]float64, 3)
+		f := 0.0
+		for i := range a {
+			for j := range a[i] {
+				grad[i] += a[i][j] * x[j]
+			}
+			f += 0.5*x[i]*grad[i] - b[i]*x[i]
+			grad[i] -= b[i]
+		}
+		w.Grad = tensor.New(tensor.WithShape(3), tensor.WithBacking(grad))
+		return f
+	}
+	return w, closure, minimizer
+}
+
+// TestLBFGSConvergesOnQuadratic checks that L-BFGS reaches the minimizer of a quadratic with and
+// without the line search, including with a single correction pair. It stops once the loss changes
+// by less than ToleranceChange = 1e-9, i.e. within about sqrt(1e-9) of the minimizer.
+func TestLBFGSConvergesOnQuadratic(t *testing.T) {
+	cases := []struct {
+		name  string
+		opt   *optimizer.LBFGS
+		steps int
+	}{
+		{"strong Wolfe", optimizer.DefaultLBFGS(1), 1},
+		{"fixed step", optimizer.NewLBFGS(1, 20, 100, ""), 1},
+		{"history size 1", optimizer.NewLBFGS(1, 50, 1, optimizer.LineSearchStrongWolfe), 1},
+		{"fixed step history size 1", optimizer.NewLBFGS(1, 20, 1, ""), 5},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w, closure, minimizer := quadratic(c.opt)
+			c.opt.Closure = closure
+			start := closure()
+			c.opt.Step()
+			if c.opt.Loss != start {
+				t.Errorf("Loss = %v, want the loss %v at the start of the step", c.opt.Loss, start)
+			}
+			for step := 1; step < c.steps; step++ {
+				c.opt.Step()
+			}
+			for i, v := range w.Data() {
+				if math.Abs(v-minimizer[i]) > 1e-4 {
+					t.Fatalf("w = %v after %d steps, want %v", w.Data(), c.steps, minimizer)
+				}
+			}
+		})
+	}
+}
+
+// TestLBFGSStrongWolfeRosenbrock checks that the line search carries L-BFGS along the curved
+// valley of the Rosenbrock function (1 - x)^2 + 100 * (y - x^2)^2 to its minimum at (1, 1)
+func TestLBFGSStrongWolfeRosenbrock(t *testing.T) {
+	o := optimizer.NewLBFGS(1, 200, 10, optimizer.LineSearchStrongWolfe)
+	o.MaxEval = 400
+	w := autograd.NewParameter("w", tensor.New(tensor.WithShape(2), tensor.WithBacking([]float64{-1.5, 2})))
+	o.SetParameters([]*autograd.Parameter{w})
+	o.Closure = func() float64 {
+		o.ZeroGrad()
+		x, y := w.Data()[0], w.Data()[1]
+		grad := []float64{-2*(1-x) - 400*x*(y-x*x), 200 * (y - x*x)}
+		w.Grad = tensor.New(tensor.WithShape(2), tensor.WithBacking(grad))
+		return (1-x)*(1-x) + 100*(y-x*x)*(y-x*x)
+	}
+	o.Step()
+
+	for i, v := range w.Data() {
+		if math.Abs(v-1) > 1e-4 {
+			t.Fatalf("w[%d] = %v, want 1", i, v)
+		}
+	}
+}
+
+func TestNewLBFGSRejectsInvalidSettings(t *testing.T) {
+	cases := []struct {
+		name                 string
+		maxIter, historySize int
+		lineSearch           string
+	}{
+		{"zero history", 20, 0, ""},
+		{"zero iterations", 0, 10, ""},
+		{"unknown line search", 20, 10, "backtracking"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewLBFGS(1, %d, %d, %q) did not panic", c.maxIter, c.historySize, c.lineSearch)
+				}
+			}()
+			optimizer.NewLBFGS(1, c.maxIter, c.historySize, c.lineSearch)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// Lion implements the sign-based Lion optimizer (Chen et al.), which only tracks a momentum
+// buffer and moves every coordinate by the same magnitude:
+//
+//	c = beta1 * m + (1 - beta1) * gradient
+//	parameter = parameter - learning_rate * (sign(c) + weight_decay * parameter)
+//	m = beta2 * m + (1 - beta2) * gradient
+//
+// Because the update has unit magnitude per coordinate, learning rates are typically
+// 3-10x smaller than for Adam, with correspondingly larger weight decay.
+type Lion struct {
+	LR          float64
+	Beta1       float64
+	Beta2       float64
+	WeightDecay float64
+	m           map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewLion creates a new Lion optimizer with the specified parameters.
+// Common values are lr 0.0001, beta1 0.9, beta2 0.99 and weightDecay 0.
+func NewLion(lr, beta1, beta2, weightDecay float64) *Lion {
+	return &Lion{
+		LR:          lr,
+		Beta1:       beta1,
+		Beta2:       beta2,
+		WeightDecay: weightDecay,
+		m:           make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultLion creates a new Lion optimizer with the specified learning rate
+// and default parameters (beta1=0.9, beta2=0.99, weight_decay=0).
+func DefaultLion(lr float64) *Lion {
+	return NewLion(lr, 0.9, 0.99, 0)
+}
+
+func (a *Lion) Step() {
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		mData := state(a.m, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			paramData[i] -= a.LR * a.WeightDecay * paramData[i]
+			c := a.Beta1*mData[i] + (1-a.Beta1)*g
+			if c != 0 {
+				paramData[i] -= a.LR * math.Copysign(1, c)
+			}
+			mData[i] = a.Beta2*mData[i] + (1-a.Beta2)*g
+		}
+	}
+}
+
+func (a *Lion) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *Lion) SetLearningRate(lr float64) {
+	a.LR = lr
+}
@@ -0,0 +1,86 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// Nadam implements Adam with Nesterov momentum, which applies the momentum step of the
+// next iteration to the current gradient. It follows the standard PyTorch formulation:
+//
+//	mu_t = beta1 * (1 - 0.5 * 0.96^(t * momentum_decay))
+//	m_t = beta1 * m_{t-1} + (1 - beta1) * gradient
+//	v_t = beta2 * v_{t-1} + (1 - beta2) * gradient^2
+//	denom = sqrt(v_t / (1 - beta2^t)) + epsilon
+//	parameter = parameter - learning_rate * (1 - mu_t) / (1 - prod(mu_1..mu_t)) * gradient / denom
+//	parameter = parameter - learning_rate * mu_{t+1} / (1 - prod(mu_1..mu_{t+1})) * m_t / denom
+type Nadam struct {
+	LR            float64
+	Beta1         float64
+	Beta2         float64
+	Epsilon       float64
+	MomentumDecay float64
+	T             int
+	muProduct     float64
+	m             map[*autograd.Parameter][]float64
+	v             map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewNadam creates a new Nadam optimizer with the specified parameters.
+// Common values are lr 0.002, beta1 0.9, beta2 0.999, epsilon 1e-8 and momentumDecay 0.004.
+func NewNadam(lr, beta1, beta2, epsilon, momentumDecay float64) *Nadam {
+	return &Nadam{
+		LR:            lr,
+		Beta1:         beta1,
+		Beta2:         beta2,
+		Epsilon:       epsilon,
+		MomentumDecay: momentumDecay,
+		muProduct:     1,
+		m:             make(map[*autograd.Parameter][]float64),
+		v:             make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultNadam creates a new Nadam optimizer with the specified learning rate
+// and default parameters (beta1=0.9, beta2=0.999, epsilon=1e-8, momentum_decay=0.004).
+func DefaultNadam(lr float64) *Nadam {
+	return NewNadam(lr, 0.9, 0.999, 1e-8, 0.004)
+}
+
+func (a *Nadam) Step() {
+	a.T++
+	mu := a.Beta1 * (1 - 0.5*math.Pow(0.96, float64(a.T)*a.MomentumDecay))
+	muNext := a.Beta1 * (1 - 0.5*math.Pow(0.96, float64(a.T+1)*a.MomentumDecay))
+	a.muProduct *= mu
+	gradCoef := a.LR * (1 - mu) / (1 - a.muProduct)
+	momentumCoef := a.LR * muNext / (1 - a.muProduct*muNext)
+	beta2_t := math.Pow(a.Beta2, float64(a.T))
+
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		mData := state(a.m, p)
+		vData := state(a.v, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			mData[i] = a.Beta1*mData[i] + (1-a.Beta1)*g
+			vData[i] = a.Beta2*vData[i] + (1-a.Beta2)*g*g
+			denom := math.Sqrt(vData[i]/(1-beta2_t)) + a.Epsilon
+			paramData[i] -= (gradCoef*g + momentumCoef*mData[i]) / denom
+		}
+	}
+}
+
+func (a *Nadam) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *Nadam) SetLearningRate(lr float64) {
+	a.LR = lr
+}
@@ -1,10 +1,48 @@
 package optimizer
 
-import "gorgonia.org/tensor"
+import "github.com/VigyatGoel/gotorch/autograd"
 
 type Optimizer interface {
-	Step(weights *tensor.Dense, gradients *tensor.Dense) *tensor.Dense
-	StepBias(biases *tensor.Dense, biasGradients *tensor.Dense) *tensor.Dense
-	ZeroGrad()
+	SetParameters(params []*autograd.Parameter) // registers the parameters to optimize
+	Parameters() []*autograd.Parameter          // returns the registered parameters
+	Step()                                      // updates parameters using their accumulated gradients
+	ZeroGrad()                                  // clears the gradients of all registered parameters
 	GetLearningRate() float64
+	SetLearningRate(lr float64) // overrides the learning rate, e.g. from a scheduler
+}
+
+// parameterList holds the parameters registered with an optimizer and
+// implements the registration and gradient-clearing part of Optimizer
+type parameterList struct {
+	params []*autograd.Parameter
+}
+
+func (l *parameterList) SetParameters(params []*autograd.Parameter) {
+	l.params = params
+}
+
+func (l *parameterList) Parameters() []*autograd.Parameter {
+	return l.params
+}
+
+// ZeroGrad clears the gradients of all registered parameters, leaving optimizer state untouched.
+// Parameters that receive no gradient before the next Step are skipped by it.
+func (l *parameterList) ZeroGrad() {
+	for _, p := range l.params {
+		p.ZeroGrad()
+	}
+}
+
+// trainable reports whether p should be updated by an optimizer step: it must require
+// gradients and have received one since the last ZeroGrad
+func trainable(p *autograd.Parameter) bool {
+	return p != nil && p.RequiresGrad && p.Grad != nil
+}
+
+// state returns the per-parameter buffer stored in states for p, allocating zeros on first use
+func state(states map[*autograd.Parameter][]float64, p *autograd.Parameter) []float64 {
+	if _, ok := states[p]; !ok {
+		states[p] = make([]float64, len(p.Data()))
+	}
+	return states[p]
 }
@@ -0,0 +1,121 @@
+package optimizer_test
+
+import (
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/layer"
+	"github.com/VigyatGoel/gotorch/loss"
+	"github.com/VigyatGoel/gotorch/network"
+	"github.com/VigyatGoel/gotorch/optimizer"
+	"gorgonia.org/tensor"
+)
+
+// cloneLinear returns a new layer with the same weights and bias as src
+func cloneLinear(src *layer.Linear) *layer.Linear {
+	dst := layer.NewLinear(3, 3)
+	copy(dst.Weight.Data(), src.Weight.Data())
+	copy(dst.Bias.Data(), src.Bias.Data())
+	return dst
+}
+
+// TestSharedOptimizerMatchesSeparate checks that two same-shaped layers under one optimizer
+// train exactly like the same layers each under their own optimizer, i.e. state is kept per parameter
+func TestSharedOptimizerMatchesSeparate(t *testing.T) {
+	cases := []struct {
+		name string
+		new  func() optimizer.Optimizer
+	}{
+		{"Adam", func() optimizer.Optimizer { return optimizer.DefaultAdam(0.01) }},
+		{"SGDMomentum", func() optimizer.Optimizer { return optimizer.DefaultSGDMomentum(0.01) }},
+	}
+
+	x := tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float64{1, 2, 3, -1, 0.5, 2}))
+	y := tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float64{0, 1, 0, 1, 0, 1}))
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a1, a2 := layer.NewLinear(3, 3), layer.NewLinear(3, 3)
+			b1, b2 := cloneLinear(a1), cloneLinear(a2)
+
+			shared := network.NewSequential(a1, layer.NewReLU(), a2)
+			shared.SetOptimizer(c.new())
+			separate := network.NewSequential(b1, layer.NewReLU(), b2)
+			opt1, opt2 := c.new(), c.new()
+			opt1.SetParameters(b1.Parameters())
+			opt2.SetParameters(b2.Parameters())
+
+			criterion := loss.NewMSELoss()
+			for step := 0; step < 20; step++ {
+				shared.ZeroGrad()
+				criterion.Forward(shared.Forward(x), y)
+				shared.Backward(criterion.Backward())
+				shared.Optimizer.Step()
+
+				separate.ZeroGrad()
+				criterion.Forward(separate.Forward(x), y)
+				separate.Backward(criterion.Backward())
+				opt1.Step()
+				opt2.Step()
+			}
+
+			for i, pair := range [][2]*layer.Linear{{a1, b1}, {a2, b2}} {
+				for j, p := range pair[0].Parameters() {
+					want := pair[1].Parameters()[j].Data()
+					for k, v := range p.Data() {
+						if v != want[k] {
+							t.Fatalf("layer %d %s[%d]: shared optimizer gave %v, separate optimizers %v", i, p.Name, k, v, want[k])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestUnusedParametersAreNotUpdated checks that after ZeroGrad, parameters of a module that
+// takes no part in a step keep their values, even under optimizers with momentum and weight decay
+func TestUnusedParametersAreNotUpdated(t *testing.T) {
+	cases := []struct {
+		name string
+		opt  optimizer.Optimizer
+	}{
+		{"Adam", optimizer.DefaultAdam(0.01)},
+		{"AdamW", optimizer.DefaultAdamW(0.01)},
+		{"SGDMomentum", optimizer.NewSGDMomentum(0.01, 0.9, optimizer.WithWeightDecay(0.1))},
+	}
+
+	x := tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float64{1, 2, 3, -1, 0.5, 2}))
+	y := tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float64{0, 1, 0, 1, 0, 1}))
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			used, unused := layer.NewLinear(3, 3), layer.NewLinear(3, 3)
+			c.opt.SetParameters(append(used.Parameters(), unused.Parameters()...))
+			criterion := loss.NewMSELoss()
+
+			// Both layers take part in the first step, building up optimizer state
+			c.opt.ZeroGrad()
+			criterion.Forward(unused.Forward(used.Forward(x)), y)
+			used.Backward(unused.Backward(criterion.Backward()))
+			c.opt.Step()
+
+			before := append(append([]float64(nil), unused.Weight.Data()...), unused.Bias.Data()...)
+
+			// Only the first layer takes part in the second step
+			c.opt.ZeroGrad()
+			criterion.Forward(used.Forward(x), y)
+			used.Backward(criterion.Backward())
+			c.opt.Step()
+
+			after := append(append([]float64(nil), unused.Weight.Data()...), unused.Bias.Data()...)
+			for i, v := range after {
+				if v != before[i] {
+					t.Fatalf("unused parameter entry %d moved from %v to %v", i, before[i], v)
+				}
+			}
+			if unused.Weight.Grad != nil {
+				t.Fatalf("unused weight still has a gradient after ZeroGrad")
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// RAdam implements rectified Adam, which disables the adaptive learning rate while the
+// variance of its estimate is intractable and rectifies it afterwards, removing the need
+// for a warmup phase. It follows the standard PyTorch formulation:
+//
+//	rho_inf = 2 / (1 - beta2) - 1
+//	rho_t = rho_inf - 2 * t * beta2^t / (1 - beta2^t)
+//	m_hat = m_t / (1 - beta1^t)
+//	if rho_t > 5:
+//	    r_t = sqrt((rho_t - 4) * (rho_t - 2) * rho_inf / ((rho_inf - 4) * (rho_inf - 2) * rho_t))
+//	    parameter = parameter - learning_rate * m_hat * r_t * sqrt(1 - beta2^t) / (sqrt(v_t) + epsilon)
+//	else:
+//	    parameter = parameter - learning_rate * m_hat
+type RAdam struct {
+	LR      float64
+	Beta1   float64
+	Beta2   float64
+	Epsilon float64
+	T       int
+	m       map[*autograd.Parameter][]float64
+	v       map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewRAdam creates a new RAdam optimizer with the specified parameters.
+// Common values are lr 0.001, beta1 0.9, beta2 0.999 and epsilon 1e-8.
+func NewRAdam(lr, beta1, beta2, epsilon float64) *RAdam {
+	return &RAdam{
+		LR:      lr,
+		Beta1:   beta1,
+		Beta2:   beta2,
+		Epsilon: epsilon,
+		m:       make(map[*autograd.Parameter][]float64),
+		v:       make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultRAdam creates a new RAdam optimizer with the specified learning rate
+// and default parameters (beta1=0.9, beta2=0.999, epsilon=1e-8).
+func DefaultRAdam(lr float64) *RAdam {
+	return NewRAdam(lr, 0.9, 0.999, 1e-8)
+}
+
+func (a *RAdam) Step() {
+	a.T++
+	beta1_t := math.Pow(a.Beta1, float64(a.T))
+	beta2_t := math.Pow(a.Beta2, float64(a.T))
+	rhoInf := 2/(1-a.Beta2) - 1
+	rho := rhoInf - 2*float64(a.T)*beta2_t/(1-beta2_t)
+	rectified := rho > 5
+	rect := 0.0
+	if rectified {
+		rect = math.Sqrt((rho - 4) * (rho - 2) * rhoInf / ((rhoInf - 4) * (rhoInf - 2) * rho))
+	}
+
+	for _, p := range a.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		mData := state(a.m, p)
+		vData := state(a.v, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			mData[i] = a.Beta1*mData[i] + (1-a.Beta1)*g
+			vData[i] = a.Beta2*vData[i] + (1-a.Beta2)*g*g
+			mHat := mData[i] / (1 - beta1_t)
+			if !rectified {
+				// Too few steps for a reliable variance estimate: plain momentum SGD
+				paramData[i] -= a.LR * mHat
+				continue
+			}
+			adaptive := math.Sqrt(1-beta2_t) / (math.Sqrt(vData[i]) + a.Epsilon)
+			paramData[i] -= a.LR * mHat * rect * adaptive
+		}
+	}
+}
+
+func (a *RAdam) GetLearningRate() float64 {
+	return a.LR
+}
+
+func (a *RAdam) SetLearningRate(lr float64) {
+	a.LR = lr
+}
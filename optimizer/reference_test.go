@@ -0,0 +1,170 @@
+package optimizer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+	"github.com/VigyatGoel/gotorch/optimizer"
+	"gorgonia.org/tensor"
+)
+
+// referenceStart and referenceGrads are the parameter and the per-step gradients the reference
+// values were computed from; gradients are reused cyclically when a case runs more steps
+var (
+	referenceStart = []float64{0.5, -1.0, 2.0}
+	referenceGrads = [][]float64{
+		{0.1, -0.2, 0.3},
+		{0.05, 0.1, -0.4},
+		{-0.3, 0.2, 0.1},
+		{0.2, -0.1, 0.05},
+		{0.0, 0.3, -0.2},
+		{0.15, -0.25, 0.1},
+		{-0.1, 0.05, 0.2},
+		{0.3, 0.1, -0.1},
+	}
+)
+
+// referenceCase runs an optimizer for a number of steps and lists the parameter values the
+// reference algorithm (PyTorch's, unless noted otherwise) reaches from the same start and gradients
+type referenceCase struct {
+	name  string
+	opt   optimizer.Optimizer
+	start []float64   // referenceStart if nil
+	grads [][]float64 // referenceGrads if nil
+	steps int
+	want  []float64
+}
+
+// runReference applies each case's gradients for its steps and compares the final parameter
+func runReference(t *testing.T, cases []referenceCase) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			start, grads := c.start, c.grads
+			if start == nil {
+				start = referenceStart
+			}
+			if grads == nil {
+				grads = referenceGrads
+			}
+
+			p := autograd.NewParameter("w", tensor.New(tensor.WithShape(len(start)), tensor.WithBacking(append([]float64(nil), start...))))
+			c.opt.SetParameters([]*autograd.Parameter{p})
+			for step := 0; step < c.steps; step++ {
+				c.opt.ZeroGrad()
+				g := grads[step%len(grads)]
+				p.Grad = tensor.New(tensor.WithShape(len(g)), tensor.WithBacking(append([]float64(nil), g...)))
+				c.opt.Step()
+			}
+
+			for i, v := range p.Data() {
+				if math.Abs(v-c.want[i]) > 1e-12 {
+					t.Errorf("w[%d] = %.17g after %d steps, want %.17g", i, v, c.steps, c.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAdaptiveOptimizersMatchReference(t *testing.T) {
+	runReference(t, []referenceCase{
+		{
+			name:  "AdamW",
+			opt:   optimizer.NewAdamW(0.1, 0.9, 0.999, 1e-8, 0.1),
+			steps: 5,
+			want:  []float64{0.31075062003947995, -0.8978666900504964, 1.8396471043690228},
+		},
+		{
+			name:  "RMSProp",
+			opt:   optimizer.NewRMSProp(0.01, 0.99, 1e-8, 0, false),
+			steps: 5,
+			want:  []float64{0.39569101583889504, -1.049324982929316, 1.987418950464052},
+		},
+		{
+			name:  "RMSProp centered with momentum",
+			opt:   optimizer.NewRMSProp(0.01, 0.9, 1e-8, 0.9, true),
+			steps: 5,
+			want:  []float64{0.3569246760523277, -0.9785100830668252, 1.9412049391584048},
+		},
+		{
+			name:  "Adagrad with learning rate decay",
+			opt:   optimizer.NewAdagrad(0.1, 0.5, 1e-10),
+			steps: 5,
+			want:  []float64{0.39584537127119046, -0.9734400358040056, 1.9517451384289897},
+		},
+		{
+			name:  "Adadelta",
+			opt:   optimizer.NewAdadelta(1.0, 0.9, 1e-6),
+			steps: 5,
+			want:  []float64{0.4957002334989617, -1.0046840311768828, 2.0009646985460576},
+		},
+	})
+}
+
+func TestModernOptimizersMatchReference(t *testing.T) {
+	runReference(t, []referenceCase{
+		{
+			name:  "Nadam",
+			opt:   optimizer.NewNadam(0.01, 0.9, 0.999, 1e-8, 0.004),
+			steps: 5,
+			want:  []float64{0.4881698331129109, -1.0066436391927087, 1.9990760068196887},
+		},
+		{
+			// the variance estimate is not yet tractable (rho_1 = 1 <= 5): plain p - lr*g
+			name:  "RAdam first step",
+			opt:   optimizer.NewRAdam(0.1, 0.9, 0.999, 1e-8),
+			steps: 1,
+			want:  []float64{0.49, -0.98, 1.97},
+		},
+		{
+			// rho_3 is about 3, so all steps are SGD with bias-corrected momentum
+			name:  "RAdam unrectified",
+			opt:   optimizer.NewRAdam(0.1, 0.9, 0.999, 1e-8),
+			steps: 3,
+			want:  []float64{0.48905224315401047, -0.9805127209166828, 1.9774694115362206},
+		},
+		{
+			// rho_t first exceeds 5 at step 6, so the last three steps are rectified
+			name:  "RAdam rectified",
+			opt:   optimizer.NewRAdam(0.1, 0.9, 0.999, 1e-8),
+			steps: 8,
+			want:  []float64{0.48461178701293534, -0.9896011190804802, 1.9801566947853355},
+		},
+		{
+			name:  "LAMB",
+			opt:   optimizer.NewLAMB(0.01, 0.9, 0.999, 1e-6, 0.01),
+			steps: 5,
+			want:  []float64{0.4628390440251817, -1.0194828818913437, 1.9845221330179037},
+		},
+		{
+			// zero weight norm: the trust ratio falls back to 1
+			name:  "LAMB zero weights",
+			opt:   optimizer.NewLAMB(0.01, 0.9, 0.999, 1e-6, 0.01),
+			start: []float64{0, 0, 0},
+			steps: 1,
+			want:  []float64{-0.009999900000999989, 0.009999950000249998, -0.00999996666677778},
+		},
+		{
+			// zero update norm: the trust ratio falls back to 1 and the weights stay put
+			name:  "LAMB zero update",
+			opt:   optimizer.NewLAMB(0.01, 0.9, 0.999, 1e-6, 0),
+			grads: [][]float64{{0, 0, 0}},
+			steps: 3,
+			want:  []float64{0.5, -1.0, 2.0},
+		},
+		{
+			// reference: the Lion paper (Chen et al., 2023), decoupled weight decay first
+			name:  "Lion first step",
+			opt:   optimizer.NewLion(0.01, 0.9, 0.99, 0.1),
+			steps: 1,
+			want:  []float64{0.4895, -0.989, 1.988},
+		},
+		{
+			name:  "Lion",
+			opt:   optimizer.NewLion(0.01, 0.9, 0.99, 0.1),
+			steps: 5,
+			want:  []float64{0.4675649150524895, -1.0050099700349888, 1.9800599400399879},
+		},
+	})
+}
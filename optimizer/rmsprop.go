@@ -0,0 +1,88 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/VigyatGoel/gotorch/autograd"
+)
+
+// RMSProp implements the RMSProp optimizer, which divides the gradient by a running
+// root mean square of recent gradients. It follows the standard PyTorch formulation:
+//
+//	square_avg = alpha * square_avg + (1 - alpha) * gradient^2
+//	avg = sqrt(square_avg) + epsilon
+//	parameter = parameter - learning_rate * gradient / avg
+//
+// The centered variant also tracks the mean gradient and normalizes by the estimated
+// variance instead, avg = sqrt(square_avg - grad_avg^2) + epsilon. With momentum, the
+// normalized gradient is accumulated into a buffer, buf = momentum * buf + gradient / avg,
+// and the parameter moves by learning_rate * buf.
+type RMSProp struct {
+	LR       float64
+	Alpha    float64
+	Epsilon  float64
+	Momentum float64
+	Centered bool
+	square   map[*autograd.Parameter][]float64
+	mean     map[*autograd.Parameter][]float64
+	buffer   map[*autograd.Parameter][]float64
+	parameterList
+}
+
+// NewRMSProp creates a new RMSProp optimizer with the specified parameters.
+// Common values are lr 0.01, alpha 0.99, epsilon 1e-8 and momentum 0.
+func NewRMSProp(lr, alpha, epsilon, momentum float64, centered bool) *RMSProp {
+	return &RMSProp{
+		LR:       lr,
+		Alpha:    alpha,
+		Epsilon:  epsilon,
+		Momentum: momentum,
+		Centered: centered,
+		square:   make(map[*autograd.Parameter][]float64),
+		mean:     make(map[*autograd.Parameter][]float64),
+		buffer:   make(map[*autograd.Parameter][]float64),
+	}
+}
+
+// DefaultRMSProp creates a new RMSProp optimizer with the specified learning rate
+// and default parameters (alpha=0.99, epsilon=1e-8, no momentum, not centered).
+func DefaultRMSProp(lr float64) *RMSProp {
+	return NewRMSProp(lr, 0.99, 1e-8, 0, false)
+}
+
+func (r *RMSProp) Step() {
+	for _, p := range r.params {
+		if !trainable(p) {
+			continue
+		}
+
+		paramData := p.Data()
+		squareData := state(r.square, p)
+		gradData := p.Grad.Data().([]float64)
+		for i := range paramData {
+			g := gradData[i]
+			squareData[i] = r.Alpha*squareData[i] + (1-r.Alpha)*g*g
+			variance := squareData[i]
+			if r.Centered {
+				meanData := state(r.mean, p)
+				meanData[i] = r.Alpha*meanData[i] + (1-r.Alpha)*g
+				variance -= meanData[i] * meanData[i]
+			}
+			step := g / (math.Sqrt(variance) + r.Epsilon)
+			if r.Momentum > 0 {
+				bufferData := state(r.buffer, p)
+				bufferData[i] = r.Momentum*bufferData[i] + step
+				step = bufferData[i]
+			}
+			paramData[i] -= r.LR * step
+		}
+	}
+}
+
+func (r *RMSProp) GetLearningRate() float64 {
+	return r.LR
+}
+
+func (r *RMSProp) SetLearningRate(lr float64) {
+	r.LR = lr
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/optimizer"
+)
+
+// CosineAnnealingLR anneals the learning rate from its base value to EtaMin
+// along half a cosine period over TMax epochs
+type CosineAnnealingLR struct {
+	schedule
+	TMax   int     // epochs from the base learning rate to EtaMin
+	EtaMin float64 // minimum learning rate
+}
+
+// NewCosineAnnealingLR creates a new cosine annealing schedule for opt
+func NewCosineAnnealingLR(opt optimizer.Optimizer, tMax int, etaMin float64) *CosineAnnealingLR {
+	if tMax <= 0 {
+		panic(fmt.Sprintf("CosineAnnealingLR: TMax %d must be positive", tMax))
+	}
+	s := &CosineAnnealingLR{schedule: newSchedule(opt), TMax: tMax, EtaMin: etaMin}
+	s.apply(s.learningRate)
+	return s
+}
+
+// Step advances one epoch: lr = eta_min + (base_lr - eta_min) * (1 + cos(pi * epoch / T_max)) / 2
+func (s *CosineAnnealingLR) Step() {
+	s.advance(s.learningRate)
+}
+
+func (s *CosineAnnealingLR) learningRate(epoch int) float64 {
+	return cosineAnneal(s.BaseLR, s.EtaMin, float64(epoch)/float64(s.TMax))
+}
+
+// CosineAnnealingWarmRestarts (SGDR) anneals the learning rate to EtaMin along a cosine and then
+// restarts it at its base value. The first cycle lasts T0 epochs and every later cycle TMult times
+// longer than the previous one.
+type CosineAnnealingWarmRestarts struct {
+	schedule
+	T0     int     // epochs in the first cycle
+	TMult  int     // growth factor of the cycle length after each restart
+	EtaMin float64 // minimum learning rate
+}
+
+// NewCosineAnnealingWarmRestarts creates a new cosine annealing schedule with warm restarts for opt
+func NewCosineAnnealingWarmRestarts(opt optimizer.Optimizer, t0, tMult int, etaMin float64) *CosineAnnealingWarmRestarts {
+	if t0 <= 0 || tMult < 1 {
+		panic(fmt.Sprintf("CosineAnnealingWarmRestarts: T0 %d must be positive and TMult %d at least 1", t0, tMult))
+	}
+	s := &CosineAnnealingWarmRestarts{schedule: newSchedule(opt), T0: t0, TMult: tMult, EtaMin: etaMin}
+	s.apply(s.learningRate)
+	return s
+}
+
+// Step advances one epoch: lr = eta_min + (base_lr - eta_min) * (1 + cos(pi * T_cur / T_i)) / 2,
+// where T_cur counts the epochs since the last restart and T_i is the length of the current cycle
+func (s *CosineAnnealingWarmRestarts) Step() {
+	s.advance(s.learningRate)
+}
+
+func (s *CosineAnnealingWarmRestarts) learningRate(epoch int) float64 {
+	cur, length := epoch, s.T0
+	for cur >= length {
+		cur -= length
+		length *= s.TMult
+	}
+	return cosineAnneal(s.BaseLR, s.EtaMin, float64(cur)/float64(length))
+}
+
+// cosineAnneal interpolates from start at progress 0 to end at progress 1 along half a cosine period
+func cosineAnneal(start, end, progress float64) float64 {
+	return end + (start-end)*(1+math.Cos(math.Pi*progress))/2
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/VigyatGoel/gotorch/optimizer"
+)
+
+// StepLR multiplies the learning rate by Gamma every StepSize epochs
+type StepLR struct {
+	schedule
+	StepSize int     // epochs between decays
+	Gamma    float64 // multiplicative decay factor
+}
+
+// NewStepLR creates a new step decay schedule for opt
+func NewStepLR(opt optimizer.Optimizer, stepSize int, gamma float64) *StepLR {
+	if stepSize <= 0 {
+		panic(fmt.Sprintf("StepLR: step size %d must be positive", stepSize))
+	}
+	s := &StepLR{schedule: newSchedule(opt), StepSize: stepSize, Gamma: gamma}
+	s.apply(s.learningRate)
+	return s
+}
+
+// Step advances one epoch: lr = base_lr * gamma^(epoch / step_size)
+func (s *StepLR) Step() {
+	s.advance(s.learningRate)
+}
+
+func (s *StepLR) learningRate(epoch int) float64 {
+	return s.BaseLR * math.Pow(s.Gamma, float64(epoch/s.StepSize))
+}
+
+// MultiStepLR multiplies the learning rate by Gamma at every milestone epoch
+type MultiStepLR struct {
+	schedule
+	Milestones []int   // epochs at which the learning rate decays
+	Gamma      float64 // multiplicative decay factor
+}
+
+// NewMultiStepLR creates a new milestone decay schedule for opt
+func NewMultiStepLR(opt optimizer.Optimizer, milestones []int, gamma float64) *MultiStepLR {
+	sorted := append([]int(nil), milestones...)
+	sort.Ints(sorted)
+	s := &MultiStepLR{schedule: newSchedule(opt), Milestones: sorted, Gamma: gamma}
+	s.apply(s.learningRate)
+	return s
+}
+
+// Step advances one epoch: lr = base_lr * gamma^(number of milestones reached)
+func (s *MultiStepLR) Step() {
+	s.advance(s.learningRate)
+}
+
+func (s *MultiStepLR) learningRate(epoch int) float64 {
+	reached := 0
+	for _, m := range s.Milestones {
+		if epoch >= m {
+			reached++
+		}
+	}
+	return s.BaseLR * math.Pow(s.Gamma, float64(reached))
+}
+
+// ExponentialLR multiplies the learning rate by Gamma every epoch
+type ExponentialLR struct {
+	schedule
+	Gamma float64 // multiplicative decay factor
+}
+
+// NewExponentialLR creates a new exponential decay schedule for opt
+func NewExponentialLR(opt optimizer.Optimizer, gamma float64) *ExponentialLR {
+	s := &ExponentialLR{schedule: newSchedule(opt), Gamma: gamma}
+	s.apply(s.learningRate)
+	return s
+}
+
+// Step advances one epoch: lr = base_lr * gamma^epoch
+func (s *ExponentialLR) Step() {
+	s.advance(s.learningRate)
+}
+
+func (s *ExponentialLR) learningRate(epoch int) float64 {
+	return s.BaseLR * math.Pow(s.Gamma, float64(epoch))
+}
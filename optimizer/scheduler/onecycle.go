@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/optimizer"
+)
+
+// OneCycleLR implements the 1cycle policy (Smith & Topsekar): the learning rate rises from
+// MaxLR / DivFactor to MaxLR over the first PctStart of TotalSteps, then anneals to
+// MaxLR / (DivFactor * FinalDivFactor), both along cosine curves. Step is called after every batch.
+// Unlike PyTorch, momentum is not cycled.
+type OneCycleLR struct {
+	schedule
+	MaxLR          float64 // peak learning rate
+	TotalSteps     int     // number of Step calls in the whole cycle
+	PctStart       float64 // fraction of the cycle spent increasing the learning rate
+	DivFactor      float64 // initial learning rate = MaxLR / DivFactor
+	FinalDivFactor float64 // final learning rate = initial learning rate / FinalDivFactor
+}
+
+// NewOneCycleLR creates a new one-cycle schedule for opt, replacing its learning rate
+func NewOneCycleLR(opt optimizer.Optimizer, maxLR float64, totalSteps int, pctStart, divFactor, finalDivFactor float64) *OneCycleLR {
+	if pctStart <= 0 || pctStart >= 1 || pctStart*float64(totalSteps) <= 1 || (1-pctStart)*float64(totalSteps) <= 0 {
+		panic(fmt.Sprintf("OneCycleLR: %d total steps with pctStart %v leave no room for both phases", totalSteps, pctStart))
+	}
+	s := &OneCycleLR{
+		schedule:       newSchedule(opt),
+		MaxLR:          maxLR,
+		TotalSteps:     totalSteps,
+		PctStart:       pctStart,
+		DivFactor:      divFactor,
+		FinalDivFactor: finalDivFactor,
+	}
+	s.apply(s.learningRate)
+	return s
+}
+
+// DefaultOneCycleLR creates a new one-cycle schedule for opt with the specified peak learning rate
+// and total steps, and default parameters (pct_start=0.3, div_factor=25, final_div_factor=1e4).
+func DefaultOneCycleLR(opt optimizer.Optimizer, maxLR float64, totalSteps int) *OneCycleLR {
+	return NewOneCycleLR(opt, maxLR, totalSteps, 0.3, 25, 1e4)
+}
+
+// Step advances one batch along the cycle
+func (s *OneCycleLR) Step() {
+	if s.LastEpoch+1 >= s.TotalSteps {
+		panic(fmt.Sprintf("OneCycleLR: stepped more than the %d total steps", s.TotalSteps))
+	}
+	s.advance(s.learningRate)
+}
+
+func (s *OneCycleLR) learningRate(step int) float64 {
+	initial := s.MaxLR / s.DivFactor
+	final := initial / s.FinalDivFactor
+	peak := s.PctStart*float64(s.TotalSteps) - 1
+	last := float64(s.TotalSteps - 1)
+	if float64(step) <= peak {
+		return cosineAnneal(initial, s.MaxLR, float64(step)/peak)
+	}
+	return cosineAnneal(s.MaxLR, final, (float64(step)-peak)/(last-peak))
+}
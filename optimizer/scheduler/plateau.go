@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VigyatGoel/gotorch/optimizer"
+)
+
+const (
+	ModeMin = "min" // the monitored metric should decrease, e.g. a validation loss
+	ModeMax = "max" // the monitored metric should increase, e.g. a validation accuracy
+)
+
+// ReduceLROnPlateau multiplies the learning rate by Factor once a validation metric has not
+// improved for Patience epochs. Step takes the metric, so it does not implement Scheduler.
+type ReduceLROnPlateau struct {
+	Optimizer optimizer.Optimizer // optimizer whose learning rate is adjusted
+	Mode      string              // ModeMin or ModeMax
+	Factor    float64             // multiplicative decay factor, below 1
+	Patience  int                 // epochs without improvement before decaying
+	Threshold float64             // relative change needed to count as an improvement
+	Cooldown  int                 // epochs to wait after a decay before counting again
+	MinLR     float64             // lower bound on the learning rate
+
+	best            float64
+	badEpochs       int
+	cooldownCounter int
+}
+
+// NewReduceLROnPlateau creates a new plateau schedule for opt with a relative threshold of 1e-4,
+// no cooldown and no minimum learning rate
+func NewReduceLROnPlateau(opt optimizer.Optimizer, mode string, factor float64, patience int) *ReduceLROnPlateau {
+	if mode != ModeMin && mode != ModeMax {
+		panic(fmt.Sprintf("ReduceLROnPlateau: unknown mode %q", mode))
+	}
+	if factor >= 1 {
+		panic(fmt.Sprintf("ReduceLROnPlateau: factor %v must be below 1", factor))
+	}
+	best := math.Inf(1)
+	if mode == ModeMax {
+		best = math.Inf(-1)
+	}
+	return &ReduceLROnPlateau{
+		Optimizer: opt,
+		Mode:      mode,
+		Factor:    factor,
+		Patience:  patience,
+		Threshold: 1e-4,
+		best:      best,
+	}
+}
+
+// DefaultReduceLROnPlateau creates a new plateau schedule for opt that monitors a decreasing
+// metric, with default parameters (factor=0.1, patience=10).
+func DefaultReduceLROnPlateau(opt optimizer.Optimizer) *ReduceLROnPlateau {
+	return NewReduceLROnPlateau(opt, ModeMin, 0.1, 10)
+}
+
+// Step records the metric of one epoch and decays the learning rate on a plateau
+func (s *ReduceLROnPlateau) Step(metric float64) {
+	if s.improved(metric) {
+		s.best = metric
+		s.badEpochs = 0
+	} else {
+		s.badEpochs++
+	}
+
+	if s.cooldownCounter > 0 {
+		s.cooldownCounter--
+		s.badEpochs = 0
+	}
+
+	if s.badEpochs > s.Patience {
+		oldLR := s.Optimizer.GetLearningRate()
+		newLR := math.Max(oldLR*s.Factor, s.MinLR)
+		// Ignore decays too small to matter, as PyTorch does
+		if oldLR-newLR > 1e-8 {
+			s.Optimizer.SetLearningRate(newLR)
+		}
+		s.cooldownCounter = s.Cooldown
+		s.badEpochs = 0
+	}
+}
+
+func (s *ReduceLROnPlateau) GetLearningRate() float64 {
+	return s.Optimizer.GetLearningRate()
+}
+
+// improved reports whether metric beats the best value by more than the relative threshold
+func (s *ReduceLROnPlateau) improved(metric float64) bool {
+	if s.Mode == ModeMin {
+		return metric < s.best*(1-s.Threshold)
+	}
+	return metric > s.best*(1+s.Threshold)
+}
@@ -0,0 +1,52 @@
+// Package scheduler adjusts the learning rate of an optimizer over the course of training.
+//
+// Schedulers are created after the optimizer and take its current learning rate as the base
+// learning rate. Like in PyTorch, constructing a scheduler applies the learning rate of
+// epoch 0, and every call to Step advances one epoch (or one batch, for OneCycleLR):
+//
+//	sched := scheduler.NewStepLR(opt, 30, 0.1)
+//	for epoch := 0; epoch < epochs; epoch++ {
+//		train(...)
+//		sched.Step()
+//	}
+package scheduler
+
+import "github.com/VigyatGoel/gotorch/optimizer"
+
+// Scheduler sets the learning rate of an optimizer from the number of steps taken
+type Scheduler interface {
+	Step()                    // advances one step and updates the learning rate
+	GetLearningRate() float64 // returns the current learning rate of the optimizer
+}
+
+// schedule holds the state shared by all step-driven schedulers
+type schedule struct {
+	Optimizer optimizer.Optimizer // optimizer whose learning rate is adjusted
+	BaseLR    float64             // learning rate of the optimizer when the scheduler was created
+	LastEpoch int                 // number of Step calls so far
+}
+
+// newSchedule captures the base learning rate of opt
+func newSchedule(opt optimizer.Optimizer) schedule {
+	return schedule{Optimizer: opt, BaseLR: opt.GetLearningRate()}
+}
+
+// rebase replaces the base learning rate the schedule is computed from
+func (s *schedule) rebase(lr float64) {
+	s.BaseLR = lr
+}
+
+// apply sets the learning rate of the current epoch
+func (s *schedule) apply(lr func(epoch int) float64) {
+	s.Optimizer.SetLearningRate(lr(s.LastEpoch))
+}
+
+// advance moves to the next epoch and sets its learning rate
+func (s *schedule) advance(lr func(epoch int) float64) {
+	s.LastEpoch++
+	s.apply(lr)
+}
+
+func (s *schedule) GetLearningRate() float64 {
+	return s.Optimizer.GetLearningRate()
+}
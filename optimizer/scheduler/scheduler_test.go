@@ -0,0 +1,160 @@
+package scheduler_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/VigyatGoel/gotorch/optimizer"
+	"github.com/VigyatGoel/gotorch/optimizer/scheduler"
+)
+
+// checkSequence compares the learning rate at construction and after every Step with want,
+// which lists the learning rates PyTorch's scheduler of the same name gives epoch by epoch
+func checkSequence(t *testing.T, opt optimizer.Optimizer, s scheduler.Scheduler, want []float64) {
+	t.Helper()
+	for epoch, lr := range want {
+		if epoch > 0 {
+			s.Step()
+		}
+		if got := opt.GetLearningRate(); math.Abs(got-lr) > 1e-12 {
+			t.Fatalf("epoch %d: learning rate %v, want %v", epoch, got, lr)
+		}
+		if got := s.GetLearningRate(); got != opt.GetLearningRate() {
+			t.Fatalf("epoch %d: GetLearningRate() = %v, the optimizer has %v", epoch, got, opt.GetLearningRate())
+		}
+	}
+}
+
+func TestSchedulersMatchReference(t *testing.T) {
+	cases := []struct {
+		name string
+		new  func(opt optimizer.Optimizer) scheduler.Scheduler
+		want []float64
+	}{
+		{
+			name: "StepLR",
+			new:  func(opt optimizer.Optimizer) scheduler.Scheduler { return scheduler.NewStepLR(opt, 3, 0.5) },
+			want: []float64{0.1, 0.1, 0.1, 0.05, 0.05, 0.05, 0.025, 0.025},
+		},
+		{
+			name: "MultiStepLR",
+			new: func(opt optimizer.Optimizer) scheduler.Scheduler {
+				return scheduler.NewMultiStepLR(opt, []int{5, 2}, 0.1)
+			},
+			want: []float64{0.1, 0.1, 0.01, 0.01, 0.01, 0.001, 0.001},
+		},
+		{
+			name: "ExponentialLR",
+			new:  func(opt optimizer.Optimizer) scheduler.Scheduler { return scheduler.NewExponentialLR(opt, 0.9) },
+			want: []float64{0.1, 0.09, 0.081, 0.0729, 0.06561},
+		},
+		{
+			// past TMax the learning rate rises again along the cosine, as in PyTorch
+			name: "CosineAnnealingLR",
+			new: func(opt optimizer.Optimizer) scheduler.Scheduler {
+				return scheduler.NewCosineAnnealingLR(opt, 4, 0.01)
+			},
+			want: []float64{0.1, 0.08681980515339464, 0.055, 0.023180194846605363, 0.01, 0.023180194846605356, 0.055},
+		},
+		{
+			// cycles of 2, 4 and 8 epochs starting at epochs 0, 2 and 6
+			name: "CosineAnnealingWarmRestarts",
+			new: func(opt optimizer.Optimizer) scheduler.Scheduler {
+				return scheduler.NewCosineAnnealingWarmRestarts(opt, 2, 2, 0)
+			},
+			want: []float64{0.1, 0.05, 0.1, 0.08535533905932738, 0.05, 0.014644660940672627, 0.1, 0.09619397662556434, 0.08535533905932738},
+		},
+		{
+			// the peak is reached at step pct_start * total_steps - 1 = 2
+			name: "OneCycleLR",
+			new: func(opt optimizer.Optimizer) scheduler.Scheduler {
+				return scheduler.DefaultOneCycleLR(opt, 1, 10)
+			},
+			want: []float64{0.04, 0.52, 1, 0.9504846320134737, 0.8117456539497631, 0.6112620219362893,
+				0.38874197806371075, 0.18825834605023697, 0.049519367986526286, 4e-06},
+		},
+		{
+			name: "LinearWarmup",
+			new: func(opt optimizer.Optimizer) scheduler.Scheduler {
+				return scheduler.NewLinearWarmup(opt, 4, 0.5, nil)
+			},
+			want: []float64{0.05, 0.0625, 0.075, 0.0875, 0.1, 0.1, 0.1},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			opt := optimizer.NewSGD(0.1)
+			checkSequence(t, opt, c.new(opt), c.want)
+		})
+	}
+}
+
+// TestLinearWarmupHandOver checks that warmup ramps to the optimizer's learning rate at its
+// construction and that the following schedule continues from that base learning rate, whether
+// it was created before the warmup or afterwards, when the warmup had already lowered the rate
+func TestLinearWarmupHandOver(t *testing.T) {
+	// 3 warmup steps from 0.25 * 0.1, then CosineAnnealingLR(4, 0.01) from its epoch 1
+	want := []float64{0.025, 0.05, 0.075, 0.1, 0.08681980515339464, 0.055, 0.023180194846605363, 0.01}
+
+	t.Run("created before", func(t *testing.T) {
+		opt := optimizer.NewSGD(0.1)
+		cosine := scheduler.NewCosineAnnealingLR(opt, 4, 0.01)
+		checkSequence(t, opt, scheduler.NewLinearWarmup(opt, 3, 0.25, cosine), want)
+	})
+	t.Run("created after", func(t *testing.T) {
+		opt := optimizer.NewSGD(0.1)
+		warmup := scheduler.NewLinearWarmup(opt, 3, 0.25, nil)
+		warmup.After = scheduler.NewCosineAnnealingLR(opt, 4, 0.01)
+		checkSequence(t, opt, warmup, want)
+	})
+}
+
+func TestReduceLROnPlateau(t *testing.T) {
+	cases := []struct {
+		name    string
+		new     func(opt optimizer.Optimizer) *scheduler.ReduceLROnPlateau
+		metrics []float64
+		want    []float64 // learning rate after each metric
+	}{
+		{
+			name: "min with cooldown and floor",
+			new: func(opt optimizer.Optimizer) *scheduler.ReduceLROnPlateau {
+				s := scheduler.NewReduceLROnPlateau(opt, scheduler.ModeMin, 0.5, 1)
+				s.Cooldown = 1
+				s.MinLR = 0.02
+				return s
+			},
+			metrics: []float64{1, 0.9, 0.95, 0.95, 0.95, 0.95, 0.8, 0.85, 0.85, 0.85, 0.85, 0.85},
+			want:    []float64{0.1, 0.1, 0.1, 0.05, 0.05, 0.05, 0.05, 0.05, 0.025, 0.025, 0.025, 0.02},
+		},
+		{
+			name: "max",
+			new: func(opt optimizer.Optimizer) *scheduler.ReduceLROnPlateau {
+				return scheduler.NewReduceLROnPlateau(opt, scheduler.ModeMax, 0.1, 2)
+			},
+			metrics: []float64{0.5, 0.6, 0.6, 0.6, 0.6, 0.7, 0.7},
+			want:    []float64{0.1, 0.1, 0.1, 0.1, 0.01, 0.01, 0.01},
+		},
+		{
+			// improvements smaller than the relative threshold of 1e-4 do not count
+			name: "below threshold",
+			new: func(opt optimizer.Optimizer) *scheduler.ReduceLROnPlateau {
+				return scheduler.NewReduceLROnPlateau(opt, scheduler.ModeMin, 0.5, 1)
+			},
+			metrics: []float64{1, 0.99995, 0.9999, 0.99985},
+			want:    []float64{0.1, 0.1, 0.05, 0.05},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			opt := optimizer.NewSGD(0.1)
+			s := c.new(opt)
+			for epoch, metric := range c.metrics {
+				s.Step(metric)
+				if got := s.GetLearningRate(); math.Abs(got-c.want[epoch]) > 1e-12 {
+					t.Fatalf("epoch %d: learning rate %v, want %v", epoch, got, c.want[epoch])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/VigyatGoel/gotorch/optimizer"
+)
+
+// LinearWarmup ramps the learning rate linearly from StartFactor * base_lr to base_lr over
+// WarmupSteps steps and then hands over to After, if any, or keeps the base learning rate
+type LinearWarmup struct {
+	schedule
+	WarmupSteps int       // steps until the base learning rate is reached
+	StartFactor float64   // fraction of the base learning rate used at step 0
+	After       Scheduler // optional schedule stepped once warmup is over
+}
+
+// rebaser is implemented by the schedules whose learning rate is relative to a base learning rate
+type rebaser interface {
+	rebase(lr float64)
+}
+
+// NewLinearWarmup creates a new linear warmup for opt, taking the current learning rate of opt as
+// the base learning rate. after may be nil. It may be created before or after the warmup: at
+// hand-over, a schedule relative to a base learning rate is given the warmup's base learning rate,
+// whatever the optimizer's learning rate was when it was created.
+func NewLinearWarmup(opt optimizer.Optimizer, warmupSteps int, startFactor float64, after Scheduler) *LinearWarmup {
+	if warmupSteps <= 0 || startFactor <= 0 || startFactor > 1 {
+		panic(fmt.Sprintf("LinearWarmup: need positive warmup steps (got %d) and start factor in (0, 1] (got %v)",
+			warmupSteps, startFactor))
+	}
+	s := &LinearWarmup{schedule: newSchedule(opt), WarmupSteps: warmupSteps, StartFactor: startFactor, After: after}
+	s.apply(s.learningRate)
+	return s
+}
+
+// Step advances one step: lr = base_lr * (start_factor + (1 - start_factor) * step / warmup_steps)
+// during warmup, after which After takes over
+func (s *LinearWarmup) Step() {
+	s.LastEpoch++
+	if s.LastEpoch > s.WarmupSteps && s.After != nil {
+		if r, ok := s.After.(rebaser); ok {
+			r.rebase(s.BaseLR)
+		}
+		s.After.Step()
+		return
+	}
+	s.apply(s.learningRate)
+}
+
+func (s *LinearWarmup) learningRate(epoch int) float64 {
+	if epoch >= s.WarmupSteps {
+		return s.BaseLR
+	}
+	return s.BaseLR * (s.StartFactor + (1-s.StartFactor)*float64(epoch)/float64(s.WarmupSteps))
+}
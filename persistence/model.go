@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/VigyatGoel/gotorch/autograd"
 	"github.com/VigyatGoel/gotorch/layer"
 	"github.com/VigyatGoel/gotorch/optimizer"
 	"gorgonia.org/tensor"
@@ -37,17 +38,67 @@ type ModelInterface interface {
 	GetOptimizer() optimizer.Optimizer
 }
 
+type ParameterConfig struct {
+	Name  string    `json:"name"`
+	Data  []float64 `json:"data"`
+	Shape []int     `json:"shape"`
+}
+
 type LayerConfig struct {
-	Type        string    `json:"type"`
-	InFeatures  int       `json:"in_features,omitempty"`
-	OutFeatures int       `json:"out_features,omitempty"`
+	Type        string            `json:"type"`
+	InFeatures  int               `json:"in_features,omitempty"`
+	OutFeatures int               `json:"out_features,omitempty"`
+	Parameters  []ParameterConfig `json:"parameters,omitempty"`
+	Alpha       float64           `json:"alpha,omitempty"`
+	// For convolution layers
+	InChannels  int `json:"in_channels,omitempty"`
+	OutChannels int `json:"out_channels,omitempty"`
+	KernelSize  int `json:"kernel_size,omitempty"`
+	Stride      int `json:"stride,omitempty"`
+	Padding     int `json:"padding,omitempty"`
+	Dilation    int `json:"dilation,omitempty"`
+	Groups      int `json:"groups,omitempty"`
+	// For transposed convolution
+	OutputPadding int `json:"output_padding,omitempty"`
+	// For Upsample
+	ScaleFactor int    `json:"scale_factor,omitempty"`
+	Mode        string `json:"mode,omitempty"`
+	// For adaptive pooling
+	OutputHeight int `json:"output_height,omitempty"`
+	OutputWidth  int `json:"output_width,omitempty"`
+	// For Dropout
+	DropoutRate float64 `json:"dropout_rate,omitempty"`
+	// For normalization layers
+	NumFeatures int     `json:"num_features,omitempty"`
+	Eps         float64 `json:"eps,omitempty"`
+	Momentum    float64 `json:"momentum,omitempty"`
+	// For LayerNorm and GroupNorm
+	NormalizedShape   []int `json:"normalized_shape,omitempty"`
+	ElementwiseAffine bool  `json:"elementwise_affine,omitempty"`
+	NumGroups         int   `json:"num_groups,omitempty"`
+	NumChannels       int   `json:"num_channels,omitempty"`
+	// For Embedding
+	NumEmbeddings int  `json:"num_embeddings,omitempty"`
+	EmbeddingDim  int  `json:"embedding_dim,omitempty"`
+	PaddingIdx    int  `json:"padding_idx,omitempty"`
+	Freeze        bool `json:"freeze,omitempty"`
+	// For recurrent layers
+	InputSize       int  `json:"input_size,omitempty"`
+	HiddenSize      int  `json:"hidden_size,omitempty"`
+	NumLayers       int  `json:"num_layers,omitempty"`
+	Bidirectional   bool `json:"bidirectional,omitempty"`
+	ReturnSequences bool `json:"return_sequences,omitempty"`
+	// For attention layers and positional encodings
+	EmbedDim       int  `json:"embed_dim,omitempty"`
+	NumHeads       int  `json:"num_heads,omitempty"`
+	FeedForwardDim int  `json:"feed_forward_dim,omitempty"`
+	Causal         bool `json:"causal,omitempty"`
+	MaxLen         int  `json:"max_len,omitempty"`
+	// Legacy weight/bias slots, only read when loading models saved before Parameters existed
 	Weights     []float64 `json:"weights,omitempty"`
 	WeightShape []int     `json:"weight_shape,omitempty"`
 	Biases      []float64 `json:"biases,omitempty"`
 	BiasShape   []int     `json:"bias_shape,omitempty"`
-	Alpha       float64   `json:"alpha,omitempty"`
-	// For Dropout
-	DropoutRate float64 `json:"dropout_rate,omitempty"`
 }
 
 type OptimizerConfig struct {
@@ -57,6 +108,22 @@ type OptimizerConfig struct {
 	Beta2    float64 `json:"beta2,omitempty"`
 	Epsilon  float64 `json:"epsilon,omitempty"`
 	Momentum float64 `json:"momentum,omitempty"`
+	// Optimizer-specific hyperparameters
+	WeightDecay   float64 `json:"weight_decay,omitempty"`
+	Alpha         float64 `json:"alpha,omitempty"`
+	Rho           float64 `json:"rho,omitempty"`
+	LRDecay       float64 `json:"lr_decay,omitempty"`
+	Centered      bool    `json:"centered,omitempty"`
+	MomentumDecay float64 `json:"momentum_decay,omitempty"`
+	Dampening     float64 `json:"dampening,omitempty"`
+	Nesterov      bool    `json:"nesterov,omitempty"`
+	// For LBFGS; the closure is not saved and must be set again after loading
+	MaxIter         int     `json:"max_iter,omitempty"`
+	MaxEval         int     `json:"max_eval,omitempty"`
+	HistorySize     int     `json:"history_size,omitempty"`
+	ToleranceGrad   float64 `json:"tolerance_grad,omitempty"`
+	ToleranceChange float64 `json:"tolerance_change,omitempty"`
+	LineSearch      string  `json:"line_search,omitempty"`
 }
 
 type ModelConfig struct {
@@ -87,23 +154,113 @@ func SaveModel(model ModelInterface, filePath string) error {
 			Type: layerType,
 		}
 
+		for _, p := range l.Parameters() {
+			data, shape := tensorDenseToSerializable(p.Value)
+			layerConfig.Parameters = append(layerConfig.Parameters, ParameterConfig{
+				Name:  p.Name,
+				Data:  data,
+				Shape: shape,
+			})
+		}
+
 		switch typedLayer := l.(type) {
 		case *layer.Linear:
-			wData, wShape := tensorDenseToSerializable(typedLayer.GetWeights())
-			bData, bShape := tensorDenseToSerializable(typedLayer.GetBiases())
-			if len(wShape) == 2 {
+			if wShape := typedLayer.Weight.Shape(); len(wShape) == 2 {
 				layerConfig.InFeatures = wShape[0]
 				layerConfig.OutFeatures = wShape[1]
 			}
-			layerConfig.Weights = wData
-			layerConfig.WeightShape = wShape
-			layerConfig.Biases = bData
-			layerConfig.BiasShape = bShape
+		case *layer.Conv2D:
+			layerConfig.InChannels = typedLayer.InChannels
+			layerConfig.OutChannels = typedLayer.OutChannels
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+			layerConfig.Dilation = typedLayer.Dilation
+		case *layer.Conv1D:
+			layerConfig.InChannels = typedLayer.InChannels
+			layerConfig.OutChannels = typedLayer.OutChannels
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+			layerConfig.Dilation = typedLayer.Dilation
+			layerConfig.Groups = typedLayer.Groups
+		case *layer.ConvTranspose2D:
+			layerConfig.InChannels = typedLayer.InChannels
+			layerConfig.OutChannels = typedLayer.OutChannels
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+			layerConfig.OutputPadding = typedLayer.OutputPadding
+			layerConfig.Dilation = typedLayer.Dilation
+		case *layer.Upsample:
+			layerConfig.ScaleFactor = typedLayer.ScaleFactor
+			layerConfig.Mode = typedLayer.Mode
+		case *layer.MaxPool2D:
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+		case *layer.AvgPool2D:
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+		case *layer.MaxPool1D:
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+		case *layer.AvgPool1D:
+			layerConfig.KernelSize = typedLayer.KernelSize
+			layerConfig.Stride = typedLayer.Stride
+			layerConfig.Padding = typedLayer.Padding
+		case *layer.AdaptiveAvgPool2D:
+			layerConfig.OutputHeight = typedLayer.OutputHeight
+			layerConfig.OutputWidth = typedLayer.OutputWidth
+		case *layer.BatchNorm1d:
+			layerConfig.NumFeatures = typedLayer.NumFeatures
+			layerConfig.Eps = typedLayer.Eps
+			layerConfig.Momentum = typedLayer.Momentum
+		case *layer.BatchNorm2d:
+			layerConfig.NumFeatures = typedLayer.NumFeatures
+			layerConfig.Eps = typedLayer.Eps
+			layerConfig.Momentum = typedLayer.Momentum
+		case *layer.LayerNorm:
+			layerConfig.NormalizedShape = typedLayer.NormalizedShape
+			layerConfig.Eps = typedLayer.Eps
+			layerConfig.ElementwiseAffine = typedLayer.ElementwiseAffine
+		case *layer.GroupNorm:
+			layerConfig.NumGroups = typedLayer.NumGroups
+			layerConfig.NumChannels = typedLayer.NumChannels
+			layerConfig.Eps = typedLayer.Eps
+		case *layer.Embedding:
+			layerConfig.NumEmbeddings = typedLayer.NumEmbeddings
+			layerConfig.EmbeddingDim = typedLayer.EmbeddingDim
+			layerConfig.PaddingIdx = typedLayer.PaddingIdx
+			layerConfig.Freeze = !typedLayer.Weight.RequiresGrad
+		case *layer.RNN:
+			setRecurrentConfig(&layerConfig, typedLayer.InputSize, typedLayer.HiddenSize, typedLayer.NumLayers, typedLayer.Bidirectional, typedLayer.ReturnSequences)
+		case *layer.LSTM:
+			setRecurrentConfig(&layerConfig, typedLayer.InputSize, typedLayer.HiddenSize, typedLayer.NumLayers, typedLayer.Bidirectional, typedLayer.ReturnSequences)
+		case *layer.GRU:
+			setRecurrentConfig(&layerConfig, typedLayer.InputSize, typedLayer.HiddenSize, typedLayer.NumLayers, typedLayer.Bidirectional, typedLayer.ReturnSequences)
+		case *layer.MultiHeadAttention:
+			layerConfig.EmbedDim = typedLayer.EmbedDim
+			layerConfig.NumHeads = typedLayer.NumHeads
+			layerConfig.Causal = typedLayer.Causal
+		case *layer.TransformerEncoderLayer:
+			layerConfig.EmbedDim = typedLayer.EmbedDim
+			layerConfig.NumHeads = typedLayer.NumHeads
+			layerConfig.FeedForwardDim = typedLayer.FeedForwardDim
+			layerConfig.Causal = typedLayer.Attention.Causal
+		case *layer.PositionalEncoding:
+			layerConfig.MaxLen = typedLayer.MaxLen
+			layerConfig.EmbedDim = typedLayer.EmbedDim
+		case *layer.LearnedPositionalEncoding:
+			layerConfig.MaxLen = typedLayer.MaxLen
+			layerConfig.EmbedDim = typedLayer.EmbedDim
 		case *layer.LeakyReLU:
 			layerConfig.Alpha = typedLayer.Alpha
 		case *layer.Dropout:
 			layerConfig.DropoutRate = typedLayer.DropoutRate
-		case *layer.ReLU, *layer.Sigmoid, *layer.Softmax, *layer.SiLU, *layer.Flatten:
+		case *layer.ReLU, *layer.Sigmoid, *layer.Softmax, *layer.LogSoftmax, *layer.SiLU, *layer.Flatten:
 			// No parameters to save
 		}
 
@@ -160,14 +317,63 @@ func LoadModelData(filePath string) (*ModelData, error) {
 
 		switch layerConfig.Type {
 		case "Linear":
-			linear := layer.NewLinear(layerConfig.InFeatures, layerConfig.OutFeatures)
-			if layerConfig.Weights != nil && len(layerConfig.WeightShape) > 0 {
-				linear.UpdateWeights(serializableToTensorDense(layerConfig.Weights, layerConfig.WeightShape))
-			}
-			if layerConfig.Biases != nil && len(layerConfig.BiasShape) > 0 {
-				linear.UpdateBiases(serializableToTensorDense(layerConfig.Biases, layerConfig.BiasShape))
-			}
-			newLayer = linear
+			newLayer = layer.NewLinear(layerConfig.InFeatures, layerConfig.OutFeatures)
+		case "Conv2D":
+			newLayer = layer.NewConv2D(layerConfig.InChannels, layerConfig.OutChannels, layerConfig.KernelSize,
+				layerConfig.Stride, layerConfig.Padding, layerConfig.Dilation)
+		case "ConvTranspose2D":
+			newLayer = layer.NewConvTranspose2D(layerConfig.InChannels, layerConfig.OutChannels, layerConfig.KernelSize,
+				layerConfig.Stride, layerConfig.Padding, layerConfig.OutputPadding, layerConfig.Dilation)
+		case "Upsample":
+			newLayer = layer.NewUpsample(layerConfig.ScaleFactor, layerConfig.Mode)
+		case "MaxPool2D":
+			newLayer = layer.NewMaxPool2D(layerConfig.KernelSize, layerConfig.Stride, layerConfig.Padding)
+		case "AvgPool2D":
+			newLayer = layer.NewAvgPool2D(layerConfig.KernelSize, layerConfig.Stride, layerConfig.Padding)
+		case "Conv1D":
+			newLayer = layer.NewConv1D(layerConfig.InChannels, layerConfig.OutChannels, layerConfig.KernelSize,
+				layerConfig.Stride, layerConfig.Padding, layerConfig.Dilation, layerConfig.Groups)
+		case "MaxPool1D":
+			newLayer = layer.NewMaxPool1D(layerConfig.KernelSize, layerConfig.Stride, layerConfig.Padding)
+		case "AvgPool1D":
+			newLayer = layer.NewAvgPool1D(layerConfig.KernelSize, layerConfig.Stride, layerConfig.Padding)
+		case "AdaptiveAvgPool2D":
+			newLayer = layer.NewAdaptiveAvgPool2D(layerConfig.OutputHeight, layerConfig.OutputWidth)
+		case "BatchNorm1d":
+			batchNorm := layer.NewBatchNorm1d(layerConfig.NumFeatures)
+			batchNorm.Eps = layerConfig.Eps
+			batchNorm.Momentum = layerConfig.Momentum
+			newLayer = batchNorm
+		case "BatchNorm2d":
+			batchNorm := layer.NewBatchNorm2d(layerConfig.NumFeatures)
+			batchNorm.Eps = layerConfig.Eps
+			batchNorm.Momentum = layerConfig.Momentum
+			newLayer = batchNorm
+		case "LayerNorm":
+			newLayer = layer.NewLayerNorm(layerConfig.NormalizedShape, layerConfig.Eps, layerConfig.ElementwiseAffine)
+		case "GroupNorm":
+			groupNorm := layer.NewGroupNorm(layerConfig.NumGroups, layerConfig.NumChannels)
+			groupNorm.Eps = layerConfig.Eps
+			newLayer = groupNorm
+		case "Embedding":
+			embedding := layer.NewEmbedding(layerConfig.NumEmbeddings, layerConfig.EmbeddingDim)
+			embedding.PaddingIdx = layerConfig.PaddingIdx
+			embedding.Weight.RequiresGrad = !layerConfig.Freeze
+			newLayer = embedding
+		case "RNN":
+			newLayer = layer.NewRNN(layerConfig.InputSize, layerConfig.HiddenSize, layerConfig.NumLayers, layerConfig.Bidirectional, layerConfig.ReturnSequences)
+		case "LSTM":
+			newLayer = layer.NewLSTM(layerConfig.InputSize, layerConfig.HiddenSize, layerConfig.NumLayers, layerConfig.Bidirectional, layerConfig.ReturnSequences)
+		case "GRU":
+			newLayer = layer.NewGRU(layerConfig.InputSize, layerConfig.HiddenSize, layerConfig.NumLayers, layerConfig.Bidirectional, layerConfig.ReturnSequences)
+		case "MultiHeadAttention":
+			newLayer = layer.NewMultiHeadAttention(layerConfig.EmbedDim, layerConfig.NumHeads, layerConfig.Causal)
+		case "TransformerEncoderLayer":
+			newLayer = layer.NewTransformerEncoderLayer(layerConfig.EmbedDim, layerConfig.NumHeads, layerConfig.FeedForwardDim, layerConfig.Causal)
+		case "PositionalEncoding":
+			newLayer = layer.NewPositionalEncoding(layerConfig.MaxLen, layerConfig.EmbedDim)
+		case "LearnedPositionalEncoding":
+			newLayer = layer.NewLearnedPositionalEncoding(layerConfig.MaxLen, layerConfig.EmbedDim)
 		case "Flatten":
 			newLayer = layer.NewFlatten()
 		case "LeakyReLU":
@@ -178,6 +384,8 @@ func LoadModelData(filePath string) (*ModelData, error) {
 			newLayer = layer.NewSigmoid()
 		case "Softmax":
 			newLayer = layer.NewSoftmax()
+		case "LogSoftmax":
+			newLayer = layer.NewLogSoftmax()
 		case "SiLU":
 			newLayer = layer.NewSiLU()
 		case "Dropout":
@@ -186,6 +394,10 @@ func LoadModelData(filePath string) (*ModelData, error) {
 			return nil, fmt.Errorf("unsupported layer type: %s", layerConfig.Type)
 		}
 
+		if err := loadParameters(newLayer, layerConfig); err != nil {
+			return nil, err
+		}
+
 		modelData.Layers = append(modelData.Layers, newLayer)
 	}
 
@@ -199,6 +411,50 @@ func LoadModelData(filePath string) (*ModelData, error) {
 	return modelData, nil
 }
 
+// loadParameters copies saved parameter values into the matching parameters of l
+func loadParameters(l layer.Layer, config LayerConfig) error {
+	saved := config.Parameters
+	if len(saved) == 0 {
+		if config.Weights != nil {
+			saved = append(saved, ParameterConfig{Name: "weight", Data: config.Weights, Shape: config.WeightShape})
+		}
+		if config.Biases != nil {
+			saved = append(saved, ParameterConfig{Name: "bias", Data: config.Biases, Shape: config.BiasShape})
+		}
+	}
+
+	params := make(map[string]*autograd.Parameter)
+	for _, p := range l.Parameters() {
+		params[p.Name] = p
+	}
+
+	for _, pc := range saved {
+		p, ok := params[pc.Name]
+		if !ok {
+			return fmt.Errorf("layer %s has no parameter %q", config.Type, pc.Name)
+		}
+		value := serializableToTensorDense(pc.Data, pc.Shape)
+		if value == nil {
+			continue
+		}
+		if !p.Shape().Eq(value.Shape()) {
+			return fmt.Errorf("parameter %s.%s shape mismatch: expected %v, got %v", config.Type, pc.Name, p.Shape(), value.Shape())
+		}
+		p.Value = value
+	}
+
+	return nil
+}
+
+// setRecurrentConfig records the hyperparameters shared by RNN, LSTM and GRU
+func setRecurrentConfig(config *LayerConfig, inputSize, hiddenSize, numLayers int, bidirectional, returnSequences bool) {
+	config.InputSize = inputSize
+	config.HiddenSize = hiddenSize
+	config.NumLayers = numLayers
+	config.Bidirectional = bidirectional
+	config.ReturnSequences = returnSequences
+}
+
 func getOptimizerConfig(opt optimizer.Optimizer) OptimizerConfig {
 	config := OptimizerConfig{
 		LR: opt.GetLearningRate(),
@@ -210,9 +466,62 @@ func getOptimizerConfig(opt optimizer.Optimizer) OptimizerConfig {
 		config.Beta1 = o.Beta1
 		config.Beta2 = o.Beta2
 		config.Epsilon = o.Epsilon
+	case *optimizer.AdamW:
+		config.Type = "AdamW"
+		config.Beta1 = o.Beta1
+		config.Beta2 = o.Beta2
+		config.Epsilon = o.Epsilon
+		config.WeightDecay = o.WeightDecay
+	case *optimizer.RMSProp:
+		config.Type = "RMSProp"
+		config.Alpha = o.Alpha
+		config.Epsilon = o.Epsilon
+		config.Momentum = o.Momentum
+		config.Centered = o.Centered
+	case *optimizer.Adagrad:
+		config.Type = "Adagrad"
+		config.LRDecay = o.LRDecay
+		config.Epsilon = o.Epsilon
+	case *optimizer.Adadelta:
+		config.Type = "Adadelta"
+		config.Rho = o.Rho
+		config.Epsilon = o.Epsilon
+	case *optimizer.Nadam:
+		config.Type = "Nadam"
+		config.Beta1 = o.Beta1
+		config.Beta2 = o.Beta2
+		config.Epsilon = o.Epsilon
+		config.MomentumDecay = o.MomentumDecay
+	case *optimizer.RAdam:
+		config.Type = "RAdam"
+		config.Beta1 = o.Beta1
+		config.Beta2 = o.Beta2
+		config.Epsilon = o.Epsilon
+	case *optimizer.LAMB:
+		config.Type = "LAMB"
+		config.Beta1 = o.Beta1
+		config.Beta2 = o.Beta2
+		config.Epsilon = o.Epsilon
+		config.WeightDecay = o.WeightDecay
+	case *optimizer.Lion:
+		config.Type = "Lion"
+		config.Beta1 = o.Beta1
+		config.Beta2 = o.Beta2
+		config.WeightDecay = o.WeightDecay
+	case *optimizer.LBFGS:
+		config.Type = "LBFGS"
+		config.MaxIter = o.MaxIter
+		config.MaxEval = o.MaxEval
+		config.HistorySize = o.HistorySize
+		config.ToleranceGrad = o.ToleranceGrad
+		config.ToleranceChange = o.ToleranceChange
+		config.LineSearch = o.LineSearch
 	case *optimizer.SGDMomentum:
 		config.Type = "SGDMomentum"
 		config.Momentum = o.Momentum
+		config.Dampening = o.Dampening
+		config.WeightDecay = o.WeightDecay
+		config.Nesterov = o.Nesterov
 	case *optimizer.SGD:
 		config.Type = "SGD"
 	}
@@ -224,8 +533,37 @@ func createOptimizer(config OptimizerConfig) optimizer.Optimizer {
 	switch config.Type {
 	case "Adam":
 		return optimizer.NewAdam(config.LR, config.Beta1, config.Beta2, config.Epsilon)
+	case "AdamW":
+		return optimizer.NewAdamW(config.LR, config.Beta1, config.Beta2, config.Epsilon, config.WeightDecay)
+	case "RMSProp":
+		return optimizer.NewRMSProp(config.LR, config.Alpha, config.Epsilon, config.Momentum, config.Centered)
+	case "Adagrad":
+		return optimizer.NewAdagrad(config.LR, config.LRDecay, config.Epsilon)
+	case "Adadelta":
+		return optimizer.NewAdadelta(config.LR, config.Rho, config.Epsilon)
+	case "Nadam":
+		return optimizer.NewNadam(config.LR, config.Beta1, config.Beta2, config.Epsilon, config.MomentumDecay)
+	case "RAdam":
+		return optimizer.NewRAdam(config.LR, config.Beta1, config.Beta2, config.Epsilon)
+	case "LAMB":
+		return optimizer.NewLAMB(config.LR, config.Beta1, config.Beta2, config.Epsilon, config.WeightDecay)
+	case "Lion":
+		return optimizer.NewLion(config.LR, config.Beta1, config.Beta2, config.WeightDecay)
+	case "LBFGS":
+		opt := optimizer.NewLBFGS(config.LR, config.MaxIter, config.HistorySize, config.LineSearch)
+		opt.MaxEval = config.MaxEval
+		opt.ToleranceGrad = config.ToleranceGrad
+		opt.ToleranceChange = config.ToleranceChange
+		return opt
 	case "SGDMomentum":
-		return optimizer.NewSGDMomentum(config.LR, config.Momentum)
+		opts := []optimizer.SGDMomentumOption{
+			optimizer.WithDampening(config.Dampening),
+			optimizer.WithWeightDecay(config.WeightDecay),
+		}
+		if config.Nesterov {
+			opts = append(opts, optimizer.WithNesterov())
+		}
+		return optimizer.NewSGDMomentum(config.LR, config.Momentum, opts...)
 	case "SGD":
 		return optimizer.NewSGD(config.LR)
 	default: